@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+
+	"google.golang.org/grpc"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/grpcapi"
+	"github.com/ArturoTarinVillaescusa/lift-go/httpapi"
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+	"github.com/ArturoTarinVillaescusa/lift-go/liftpb"
+	"github.com/ArturoTarinVillaescusa/lift-go/scenario"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               COMMANDS                                          ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+/**
+ *	Runs random users, or the users of a scenario file, through the building until all of them arrive, and prints
+	the step list of the elevators. The text format is followed by the report of the run. The expected outcomes of
+	the scenario which are not met are printed in the standard error, and make the command fail
+*/
+func simulate(args []string, stdout io.Writer, stderr io.Writer) error {
+	settings := &settings{}
+	flags := newFlagSet("simulate", "", stderr)
+	settings.registerBuilding(flags)
+	settings.registerDispatcher(flags)
+	settings.registerUsers(flags)
+	settings.registerScenario(flags)
+	settings.registerFormat(flags)
+	if err := parse(flags, args, settings, 0); err != nil {
+		return err
+	}
+	simulated, err := settings.newScenario(flags)
+	if err != nil {
+		return err
+	}
+
+	control, err := simulated.NewSystem()
+	if err != nil {
+		return err
+	}
+	if err := simulated.Schedule(control); err != nil {
+		return err
+	}
+	if err := control.RunUntilIdle(context.Background()); err != nil {
+		return err
+	}
+	if err := settings.printSteps(stdout, control); err != nil {
+		return err
+	}
+
+	failures := simulated.Check(control)
+	for _, failure := range failures {
+		fmt.Fprintln(stderr, "lift-go:", failure)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d expected outcomes of the scenario %q are not met", len(failures), simulated.Name)
+	}
+	return nil
+}
+
+/**
+ *	Runs random users, or the users of a scenario file, through the building for a while, and prints the status of
+	the elevators at that time
+*/
+func status(args []string, stdout io.Writer, stderr io.Writer) error {
+	settings := &settings{}
+	flags := newFlagSet("status", "", stderr)
+	settings.registerBuilding(flags)
+	settings.registerDispatcher(flags)
+	settings.registerUsers(flags)
+	settings.registerScenario(flags)
+	settings.registerFormat(flags)
+	at := flags.Int("at", 0, "time units the elevators move before the status is printed")
+	if err := parse(flags, args, settings, 0); err != nil {
+		return err
+	}
+	if *at < 0 {
+		return fmt.Errorf("the time can't be negative")
+	}
+	simulated, err := settings.newScenario(flags)
+	if err != nil {
+		return err
+	}
+
+	control, err := simulated.NewSystem()
+	if err != nil {
+		return err
+	}
+	if err := simulated.Schedule(control); err != nil {
+		return err
+	}
+	if err := control.Advance(*at); err != nil {
+		return err
+	}
+	return formats[settings.format].status.Render(stdout, control.Snapshot())
+}
+
+/**
+ *	Runs again the users of a step list exported by simulate in the jsonl or csv format, i.e. with another
+	dispatcher or in another building. Every user pushes the pick-up button at the same time of the step list.
+	The hall and car calls are not replayed
+*/
+func replay(args []string, stdout io.Writer, stderr io.Writer) error {
+	settings := &settings{}
+	flags := newFlagSet("replay", " FILE", stderr)
+	settings.registerBuilding(flags)
+	settings.registerDispatcher(flags)
+	settings.registerFormat(flags)
+	if err := parse(flags, args, settings, 1); err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if name := flags.Arg(0); name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	pickUps, skipped, err := readPickUps(input)
+	if err != nil {
+		return fmt.Errorf("%v: %w", flags.Arg(0), err)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(stderr, "lift-go: %d hall and car calls are not replayed\n", skipped)
+	}
+
+	control, err := settings.newSystem()
+	if err != nil {
+		return err
+	}
+	if err := schedule(control, pickUps); err != nil {
+		return err
+	}
+	if err := control.RunUntilIdle(context.Background()); err != nil {
+		return err
+	}
+	return settings.printSteps(stdout, control)
+}
+
+/**
+ *	Runs the same random users with every dispatcher, once per seed from -seed on, and prints a table comparing
+	the averages of the runs
+*/
+func bench(args []string, stdout io.Writer, stderr io.Writer) error {
+	settings := &settings{}
+	flags := newFlagSet("bench", "", stderr)
+	settings.registerBuilding(flags)
+	settings.registerUsers(flags)
+	runs := flags.Int("runs", 10, "runs of every dispatcher, every one with its own seed")
+	compared := flags.String("dispatchers", scenario.Names(dispatchers), "dispatchers to compare, separated by commas")
+	if err := parse(flags, args, settings, 0); err != nil {
+		return err
+	}
+	if *runs < 1 {
+		return fmt.Errorf("at least 1 run is needed")
+	}
+
+	table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "DISPATCHER\tRUNS\tJOURNEYS\tCOMPLETED\tFAILED\tWAIT MEAN\tWAIT P95\tJOURNEY MEAN\tENERGY (kWh)\tTIME/RUN\t\n")
+	for _, dispatcher := range strings.Split(*compared, ",") {
+		settings.dispatcher = strings.TrimSpace(dispatcher)
+		if err := settings.check(); err != nil {
+			return err
+		}
+
+		var total benchmark
+		for run := 0; run < *runs; run++ {
+			users := *settings
+			users.seed = settings.seed + int64(run)
+			control, err := users.newSystem()
+			if err != nil {
+				return err
+			}
+			if err := schedule(control, users.randomPickUps()); err != nil {
+				return err
+			}
+			start := time.Now()
+			if err := control.RunUntilIdle(context.Background()); err != nil {
+				return err
+			}
+			total.add(control.Report(), time.Since(start))
+		}
+		fmt.Fprintf(table, "%v\t%d\t%d\t%d\t%d\t%.1f\t%.1f\t%.1f\t%.3f\t%v\t\n", settings.dispatcher, *runs,
+			total.journeys, total.completed, total.failed, total.waitMean/float64(*runs), total.waitP95/float64(*runs),
+			total.journeyMean/float64(*runs), total.energy/float64(*runs), (total.elapsed / time.Duration(*runs)).Round(time.Microsecond))
+	}
+	return table.Flush()
+}
+
+// Serves the elevator control system as a REST API, a gRPC service, or both
+func serve(args []string, stdout io.Writer, stderr io.Writer) error {
+	settings := &settings{}
+	flags := newFlagSet("serve", "", stderr)
+	settings.registerBuilding(flags)
+	settings.registerDispatcher(flags)
+	listen := flags.String("listen", "", "address where the REST API is served, i.e. :8080")
+	grpcListen := flags.String("grpc", "", "address where the gRPC service is served, i.e. :9090")
+	if err := parse(flags, args, settings, 0); err != nil {
+		return err
+	}
+	if *listen == "" && *grpcListen == "" {
+		return fmt.Errorf("nothing to serve, use -listen, -grpc or both")
+	}
+
+	control, err := settings.newSystem()
+	if err != nil {
+		return err
+	}
+	served := make(chan error, 2)
+	if *grpcListen != "" {
+		listener, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			return err
+		}
+		server := grpc.NewServer()
+		liftpb.RegisterElevatorControlSystemServer(server, grpcapi.NewServer(control))
+		fmt.Fprintf(stderr, "Serving the gRPC service of the elevator control system in %v\n", listener.Addr())
+		go func() { served <- server.Serve(listener) }()
+	}
+	if *listen != "" {
+		fmt.Fprintf(stderr, "Serving the REST API of the elevator control system in %v\n", *listen)
+		go func() { served <- http.ListenAndServe(*listen, httpapi.NewHandler(control)) }()
+	}
+	return <-served
+}
+
+/***** COMMANDS HELPER FUNCTIONS *************/
+
+// Error of a step list to replay without any step
+var errEmptyStepList = errors.New("the step list is empty")
+
+// Sums of the reports of the runs of a dispatcher
+type benchmark struct {
+	journeys, completed, failed    int
+	waitMean, waitP95, journeyMean float64
+	energy                         float64
+	elapsed                        time.Duration
+}
+
+func (benchmark *benchmark) add(report lift.Report, elapsed time.Duration) {
+	benchmark.journeys += report.Journeys
+	benchmark.completed += report.Completed
+	benchmark.failed += report.Failed
+	benchmark.waitMean += report.WaitTime.Mean
+	benchmark.waitP95 += float64(report.WaitTime.P95)
+	benchmark.journeyMean += report.JourneyTime.Mean
+	benchmark.energy += report.Energy
+	benchmark.elapsed += elapsed
+}
+
+// The flags of a command, which report their errors instead of exiting
+func newFlagSet(name string, arguments string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: lift-go %v [flags]%v\n\n%v.\n\nFlags:\n", name, arguments, commands[name].summary)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// Parses and checks the flags of a command, which takes that number of arguments after them
+func parse(flags *flag.FlagSet, args []string, settings *settings, arguments int) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != arguments {
+		flags.Usage()
+		return fmt.Errorf("%v takes %d arguments after the flags, got %d", flags.Name(), arguments, flags.NArg())
+	}
+	return settings.check()
+}
+
+/**
+ *	Reads the pick-up requests of the users from a step list in the jsonl or the csv format, which are told by
+	their ASSIGNED steps, and tells how many steps of hall and car calls were found, which can't be replayed.
+	The requests are sorted by time
+	@ input io.Reader
+*/
+func readPickUps(input io.Reader) ([]lift.PickUpRequest, int, error) {
+	// The format is told by the first character which is not a space
+	reader := bufio.NewReader(input)
+	for {
+		first, _, err := reader.ReadRune()
+		if err == io.EOF {
+			return nil, 0, errEmptyStepList
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if !unicode.IsSpace(first) {
+			reader.UnreadRune()
+			break
+		}
+	}
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := []lift.StepRecord{}
+	if bytes.Equal(first, []byte("{")) {
+		decoder := json.NewDecoder(reader)
+		for decoder.More() {
+			// The step lists exported before called_at existed are replayed from the time of their steps
+			var record struct {
+				lift.StepRecord
+				CalledAt *int `json:"called_at"`
+			}
+			if err := decoder.Decode(&record); err != nil {
+				return nil, 0, err
+			}
+			record.StepRecord.CalledAt = record.Time
+			if record.CalledAt != nil {
+				record.StepRecord.CalledAt = *record.CalledAt
+			}
+			records = append(records, record.StepRecord)
+		}
+	} else if records, err = readCSVSteps(reader); err != nil {
+		return nil, 0, err
+	}
+
+	pickUps, skipped := []lift.PickUpRequest{}, 0
+	for _, record := range records {
+		switch record.Action {
+		case lift.Assigned.String():
+			pickUps = append(pickUps, lift.PickUpRequest{At: record.CalledAt, UserID: record.User, PickUpFloor: record.From, DropOffFloor: record.To})
+		case lift.HallCallRegistered.String(), lift.CarCallRegistered.String():
+			skipped++
+		}
+	}
+	sort.SliceStable(pickUps, func(i, j int) bool { return pickUps[i].At < pickUps[j].At })
+	return pickUps, skipped, nil
+}
+
+// Reads the steps of a step list in the csv format, finding the columns by the names of the header
+func readCSVSteps(input io.Reader) ([]lift.StepRecord, error) {
+	rows, err := csv.NewReader(input).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errEmptyStepList
+	}
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"time", "user", "action", "from", "to"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the step list has no %q column", name)
+		}
+	}
+
+	// The step lists exported before called_at existed are replayed from the time of their steps
+	_, calledAt := columns["called_at"]
+	records := []lift.StepRecord{}
+	for _, row := range rows[1:] {
+		record := lift.StepRecord{User: row[columns["user"]], Action: row[columns["action"]]}
+		fields := map[string]*int{"time": &record.Time, "from": &record.From, "to": &record.To}
+		if calledAt {
+			fields["called_at"] = &record.CalledAt
+		}
+		for name, field := range fields {
+			if *field, err = strconv.Atoi(row[columns[name]]); err != nil {
+				return nil, fmt.Errorf("%v of the step %v: %w", name, row, err)
+			}
+		}
+		if !calledAt {
+			record.CalledAt = record.Time
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+/***** END OF COMMANDS HELPER FUNCTIONS *************/
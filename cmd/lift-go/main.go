@@ -0,0 +1,83 @@
+/*
+Command lift-go runs the elevator control system from the command line, so what-if scenarios can be tried
+without editing Go code:
+
+	lift-go simulate -elevators 4 -floors 20 -users 50 -dispatcher eta
+	lift-go status -at 30 -format csv
+	lift-go replay -dispatcher energy steps.jsonl
+	lift-go bench -runs 20
+	lift-go serve -listen :8080 -grpc :9090
+
+Run lift-go <command> -h to see the flags of every command.
+*/
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// A subcommand of lift-go: it parses its own flags from the arguments, and writes its results
+type command struct {
+	summary string
+	run     func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+// The commands of lift-go by their names. They are set by init, since their usage tells their summary
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"simulate": {"runs random users through the building, and prints the step list and the report", simulate},
+		"status":   {"runs random users for a while, and prints the status of the elevators", status},
+		"replay":   {"runs again the users of a step list exported by simulate, i.e. with another dispatcher", replay},
+		"bench":    {"compares the dispatchers running the same random users with many seeds", bench},
+		"serve":    {"serves the elevator control system as a REST API and a gRPC service", serve},
+	}
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "lift-go:", err)
+		os.Exit(2)
+	}
+}
+
+// Runs the command of the arguments, or prints the usage if there's no such command
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return fmt.Errorf("no command given")
+	}
+	if args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stdout)
+		return flag.ErrHelp
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return command.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: lift-go <command> [flags]\n\nCommands:\n")
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %v\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nRun lift-go <command> -h to see the flags of the command.\n")
+}
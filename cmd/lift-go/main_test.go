@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+func TestCommandsAndTheirFlags(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		err    string
+		output string
+	}{
+		{[]string{}, "no command given", ""},
+		{[]string{"fly"}, `unknown command "fly"`, ""},
+		{[]string{"simulate", "-dispatcher", "fastest"}, `unknown dispatcher "fastest"`, ""},
+		{[]string{"simulate", "-policy", "random"}, `unknown policy "random"`, ""},
+		{[]string{"simulate", "-format", "xml"}, `unknown format "xml"`, ""},
+		{[]string{"simulate", "-elevators", "0"}, "at least 1 elevator", ""},
+		{[]string{"simulate", "extra"}, "simulate takes 0 arguments after the flags, got 1", ""},
+		{[]string{"replay"}, "replay takes 1 arguments after the flags, got 0", ""},
+		{[]string{"status", "-at", "-1"}, "the time can't be negative", ""},
+		{[]string{"bench", "-runs", "0"}, "at least 1 run is needed", ""},
+		{[]string{"serve"}, "nothing to serve", ""},
+		{[]string{"simulate", "-users", "3", "-elevators", "2"}, "", "STEP LIST OF OUR SYSTEM OF 10 FLOORS AND 2 ELEVATORS"},
+		{[]string{"simulate", "-users", "3", "-format", "csv"}, "", "time,elevator,floor,direction,user,call,action,from,to,called_at\n"},
+		{[]string{"status", "-users", "3", "-elevators", "2"}, "", "CURRENT STATUS OF THIS ELEVATOR CONTROL SYSTEM"},
+		{[]string{"status", "-users", "3", "-format", "csv"}, "", "time,elevator,floor,direction,state,persons,load,user,call,action,from,to\n"},
+		{[]string{"bench", "-runs", "2", "-dispatchers", "eta,proximity"}, "", "DISPATCHER"},
+		{[]string{"simulate", "-scenario", "missing.yaml"}, "no such file", ""},
+		{[]string{"simulate", "-scenario", "../../scenarios/out-of-service.json", "-elevators", "2"}, "unknown car 2", ""},
+		{[]string{"simulate", "-scenario", "../../scenarios/sixteen-elevators.yaml"}, "", "STEP LIST OF OUR SYSTEM OF 10 FLOORS AND 16 ELEVATORS"},
+		{[]string{"simulate", "-scenario", "../../scenarios/out-of-service.json", "-dispatcher", "proximity"}, "", "User1"},
+		{[]string{"status", "-scenario", "../../scenarios/two-elevators-thirty-floors.yaml", "-at", "10"}, "", "CURRENT STATUS OF THIS ELEVATOR CONTROL SYSTEM"},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		err := run(test.args, &stdout, &stderr)
+		if test.err == "" && err != nil || test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%v: expected the error %q, got %v", test.args, test.err, err)
+		}
+		if !strings.Contains(stdout.String(), test.output) {
+			t.Errorf("%v: expected the output to contain %q, got %v", test.args, test.output, stdout.String())
+		}
+	}
+}
+
+func TestHelpIsNotAnError(t *testing.T) {
+	for _, args := range [][]string{{"-h"}, {"simulate", "-h"}} {
+		var stdout, stderr bytes.Buffer
+		if err := run(args, &stdout, &stderr); !errors.Is(err, flag.ErrHelp) || stdout.Len()+stderr.Len() == 0 {
+			t.Errorf("%v: expected the usage, got %v", args, err)
+		}
+	}
+}
+
+func TestTheSameSeedGivesTheSameUsers(t *testing.T) {
+	simulate := func(seed string) string {
+		var stdout, stderr bytes.Buffer
+		if err := run([]string{"simulate", "-seed", seed, "-users", "20", "-period", "10", "-format", "jsonl"}, &stdout, &stderr); err != nil {
+			t.Fatal(err)
+		}
+		return stdout.String()
+	}
+	if simulate("7") != simulate("7") {
+		t.Errorf("expected the same step list with the same seed")
+	}
+	if simulate("7") == simulate("8") {
+		t.Errorf("expected another step list with another seed")
+	}
+}
+
+func TestReplayRunsAgainTheUsersOfAStepList(t *testing.T) {
+	for _, format := range []string{"jsonl", "csv"} {
+		var simulated, stderr bytes.Buffer
+		args := []string{"-elevators", "1", "-users", "8", "-period", "20", "-format", format}
+		if err := run(append([]string{"simulate"}, args...), &simulated, &stderr); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(t.TempDir(), "steps."+format)
+		if err := os.WriteFile(file, simulated.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var replayed bytes.Buffer
+		if err := run(append(append([]string{"replay"}, args[:2]...), "-format", format, file), &replayed, &stderr); err != nil {
+			t.Fatal(err)
+		}
+		if replayed.String() != simulated.String() {
+			t.Errorf("%v: expected the same step list replaying the same users, got\n%v\ninstead of\n%v", format, replayed.String(), simulated.String())
+		}
+	}
+}
+
+func TestReplayKeepsTheTimesTheQueuedUsersCalled(t *testing.T) {
+	// More users than the elevator can carry, so many of them are assigned long after they call it
+	args := []string{"-elevators", "1", "-users", "30", "-period", "5"}
+	var report, stderr bytes.Buffer
+	if err := run(append(append([]string{"simulate"}, args...), "-format", "text"), &report, &stderr); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"jsonl", "csv"} {
+		var simulated bytes.Buffer
+		if err := run(append(append([]string{"simulate"}, args...), "-format", format), &simulated, &stderr); err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(t.TempDir(), "steps."+format)
+		if err := os.WriteFile(file, simulated.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var replayed bytes.Buffer
+		if err := run([]string{"replay", "-elevators", "1", "-format", "text", file}, &replayed, &stderr); err != nil {
+			t.Fatal(err)
+		}
+		if replayed.String() != report.String() {
+			t.Errorf("%v: expected the same wait times replaying the same users, got\n%v\ninstead of\n%v", format, replayed.String(), report.String())
+		}
+	}
+}
+
+func TestReplayReadsStepListsWithLeadingSpacesAndRejectsEmptyOnes(t *testing.T) {
+	var simulated, stderr bytes.Buffer
+	if err := run([]string{"simulate", "-elevators", "1", "-users", "3", "-format", "jsonl"}, &simulated, &stderr); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		steps string
+		err   string
+	}{
+		{"", "the step list is empty"},
+		{"\n", "the step list is empty"},
+		{" \n\t\n", "the step list is empty"},
+		{"\n  " + simulated.String(), ""},
+	} {
+		file := filepath.Join(t.TempDir(), "steps")
+		if err := os.WriteFile(file, []byte(test.steps), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		var replayed bytes.Buffer
+		err := run([]string{"replay", "-elevators", "1", "-format", "jsonl", file}, &replayed, &stderr)
+		if test.err == "" && (err != nil || replayed.String() != simulated.String()) ||
+			test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%q: expected the error %q, got %v", test.steps, test.err, err)
+		}
+	}
+}
+
+func TestReplayWithAnotherDispatcherKeepsTheUsers(t *testing.T) {
+	var simulated, replayed, stderr bytes.Buffer
+	if err := run([]string{"simulate", "-elevators", "3", "-users", "10", "-period", "15", "-format", "jsonl"}, &simulated, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "steps.jsonl")
+	os.WriteFile(file, simulated.Bytes(), 0o644)
+	if err := run([]string{"replay", "-elevators", "3", "-dispatcher", "eta", "-format", "jsonl", file}, &replayed, &stderr); err != nil {
+		t.Fatal(err)
+	}
+
+	alighted := func(output string) map[string]bool {
+		users := map[string]bool{}
+		decoder := json.NewDecoder(strings.NewReader(output))
+		for decoder.More() {
+			var record lift.StepRecord
+			if err := decoder.Decode(&record); err != nil {
+				t.Fatal(err)
+			}
+			if record.Action == lift.Alighted.String() {
+				users[record.User] = true
+			}
+		}
+		return users
+	}
+	if want, got := alighted(simulated.String()), alighted(replayed.String()); len(want) != 10 || len(got) != len(want) {
+		t.Errorf("expected the same 10 users to arrive, got %v instead of %v", got, want)
+	}
+}
+
+func TestUnmetExpectationsOfAScenarioMakeSimulateFail(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "scenario.yaml")
+	scenario := "name: Impatient\nbuilding: {floors: 10, elevators: 1}\npickups: [{at: 0, user: User1, from: 10, to: 0}]\nexpect: {max_wait_time: 1}\n"
+	if err := os.WriteFile(file, []byte(scenario), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"simulate", "-scenario", file}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `1 expected outcomes of the scenario "Impatient" are not met`) {
+		t.Errorf("expected the unmet expectations to fail, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "expected a wait time of 1 at most, got 11") {
+		t.Errorf("expected the unmet expectation in the standard error, got %v", stderr.String())
+	}
+}
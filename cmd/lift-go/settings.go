@@ -0,0 +1,186 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+	"github.com/ArturoTarinVillaescusa/lift-go/scenario"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               FLAGS SHARED BY THE COMMANDS                      ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Dispatchers that can be chosen with -dispatcher, the same as in the scenario files
+var dispatchers = scenario.Dispatchers
+
+// Movement policies that can be chosen with -policy, the same as in the scenario files
+var policies = scenario.Policies
+
+// Formats that can be chosen with -format: the renderers of the step list and of the status
+var formats = map[string]struct{ steps, status lift.Renderer }{
+	"text":  {lift.StepListTextRenderer{}, lift.StatusTextRenderer{}},
+	"jsonl": {lift.StepListJSONLinesRenderer{}, lift.StatusJSONLinesRenderer{}},
+	"csv":   {lift.StepListCSVRenderer{}, lift.StatusCSVRenderer{}},
+}
+
+// The building, the elevators and the users of a run, set with the flags of the command
+type settings struct {
+	elevators  int
+	floors     int
+	dispatcher string
+	policy     string
+	format     string
+	seed       int64
+	users      int
+	period     int
+	scenario   string
+}
+
+// Registers the flags of the building and its elevators
+func (settings *settings) registerBuilding(flags *flag.FlagSet) {
+	flags.IntVar(&settings.elevators, "elevators", 16, "number of elevators")
+	flags.IntVar(&settings.floors, "floors", 10, "top floor of the building, the ground floor is 0")
+	flags.StringVar(&settings.policy, "policy", "follow", "policy moving the elevators: "+scenario.Names(policies))
+}
+
+// Registers the flag of the dispatcher
+func (settings *settings) registerDispatcher(flags *flag.FlagSet) {
+	flags.StringVar(&settings.dispatcher, "dispatcher", "proximity", "dispatcher choosing the elevator of every user: "+scenario.Names(dispatchers))
+}
+
+// Registers the flags of the random users
+func (settings *settings) registerUsers(flags *flag.FlagSet) {
+	flags.Int64Var(&settings.seed, "seed", 1, "seed of the random users, the same seed gives the same users")
+	flags.IntVar(&settings.users, "users", 14, "number of random users")
+	flags.IntVar(&settings.period, "period", 0, "last time unit when the random users push the pick-up button, from 0")
+}
+
+// Registers the flag of the scenario file
+func (settings *settings) registerScenario(flags *flag.FlagSet) {
+	flags.StringVar(&settings.scenario, "scenario", "", "YAML or JSON file with the building, the users and their expected outcomes, instead of random users")
+}
+
+// Registers the flag of the output format
+func (settings *settings) registerFormat(flags *flag.FlagSet) {
+	flags.StringVar(&settings.format, "format", "text", "output format: "+scenario.Names(formats))
+}
+
+// Checks the flags, so the errors are found before anything runs. The flags not registered are empty
+func (settings *settings) check() error {
+	if settings.elevators < 1 || settings.floors < 1 {
+		return fmt.Errorf("the building needs at least 1 elevator and 1 floor besides the ground floor")
+	}
+	if _, ok := dispatchers[settings.dispatcher]; settings.dispatcher != "" && !ok {
+		return fmt.Errorf("unknown dispatcher %q, it must be one of %v", settings.dispatcher, scenario.Names(dispatchers))
+	}
+	if _, ok := policies[settings.policy]; !ok {
+		return fmt.Errorf("unknown policy %q, it must be one of %v", settings.policy, scenario.Names(policies))
+	}
+	if _, ok := formats[settings.format]; settings.format != "" && !ok {
+		return fmt.Errorf("unknown format %q, it must be one of %v", settings.format, scenario.Names(formats))
+	}
+	if settings.users < 0 || settings.period < 0 {
+		return fmt.Errorf("the users and the period can't be negative")
+	}
+	return nil
+}
+
+// Builds the elevator control system of the flags. The ProximityDispatcher is used if there's no dispatcher
+func (settings *settings) newSystem() (lift.ElevatorControlSystem, error) {
+	options := []lift.Option{lift.WithMovementPolicy(policies[settings.policy])}
+	if dispatcher, ok := dispatchers[settings.dispatcher]; ok {
+		options = append(options, lift.WithDispatcher(dispatcher))
+	}
+	return lift.NewElevatorControlSystem(settings.elevators, settings.floors, options...)
+}
+
+/**
+ *	The users of the flags: every one of them pushes the pick-up button at a random time of the period, in a random
+	floor, to go to another random floor. The same seed gives the same users
+*/
+func (settings *settings) randomPickUps() []lift.PickUpRequest {
+	random := rand.New(rand.NewSource(settings.seed))
+	pickUps := []lift.PickUpRequest{}
+	for i := 1; i <= settings.users; i++ {
+		pickUp := lift.PickUpRequest{
+			At:          random.Intn(settings.period + 1),
+			UserID:      fmt.Sprintf("User%d", i),
+			PickUpFloor: random.Intn(settings.floors + 1),
+		}
+		// Any floor but the pick-up floor
+		pickUp.DropOffFloor = (pickUp.PickUpFloor + 1 + random.Intn(settings.floors)) % (settings.floors + 1)
+		pickUps = append(pickUps, pickUp)
+	}
+	return pickUps
+}
+
+/**
+ *	The scenario of the flags: the one of the -scenario file, whose building, dispatcher and policy are overridden
+	by the flags given, or else the random users of the flags in their building, without expected outcomes
+	@ flags *flag.FlagSet
+*/
+func (settings *settings) newScenario(flags *flag.FlagSet) (*scenario.Scenario, error) {
+	if settings.scenario == "" {
+		random := &scenario.Scenario{
+			Building:   scenario.Building{Floors: settings.floors, Elevators: settings.elevators},
+			Dispatcher: settings.dispatcher,
+			Policy:     settings.policy,
+		}
+		for _, pickUp := range settings.randomPickUps() {
+			random.PickUps = append(random.PickUps, scenario.PickUp{At: pickUp.At, User: pickUp.UserID, From: pickUp.PickUpFloor, To: pickUp.DropOffFloor})
+		}
+		return random, nil
+	}
+
+	loaded, err := scenario.Load(settings.scenario)
+	if err != nil {
+		return nil, err
+	}
+	flags.Visit(func(flag *flag.Flag) {
+		switch flag.Name {
+		case "elevators":
+			loaded.Building.Elevators = settings.elevators
+		case "floors":
+			loaded.Building.Floors = settings.floors
+		case "dispatcher":
+			loaded.Dispatcher = settings.dispatcher
+		case "policy":
+			loaded.Policy = settings.policy
+		}
+	})
+	return loaded, loaded.Validate()
+}
+
+/***** SETTINGS HELPER FUNCTIONS *************/
+
+// Schedules the pick-up requests in the system
+func schedule(control lift.ElevatorControlSystem, pickUps []lift.PickUpRequest) error {
+	for _, pickUp := range pickUps {
+		if err := control.SchedulePickUp(pickUp.At, pickUp.UserID, pickUp.PickUpFloor, pickUp.DropOffFloor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prints the step list in the format of the flags, followed by the report in the text format
+func (settings *settings) printSteps(w io.Writer, control lift.ElevatorControlSystem) error {
+	if err := formats[settings.format].steps.Render(w, control.Snapshot()); err != nil {
+		return err
+	}
+	if settings.format != "text" {
+		return nil
+	}
+	fmt.Fprintln(w)
+	return control.Report().PrintTable(w)
+}
+
+/***** END OF SETTINGS HELPER FUNCTIONS *************/
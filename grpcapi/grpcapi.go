@@ -0,0 +1,206 @@
+/*
+Package grpcapi serves an elevator control system with the gRPC contract of the liftpb package.
+
+	control, err := lift.NewElevatorControlSystem(16, 10)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := grpc.NewServer()
+	liftpb.RegisterElevatorControlSystemServer(server, grpcapi.NewServer(control))
+	server.Serve(listener)
+*/
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+	"github.com/ArturoTarinVillaescusa/lift-go/liftpb"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               GRPC SERVER                                       ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Events of the elevators a watcher can fall behind before the oldest ones are dropped
+const watchBuffer = 256
+
+// Most time units a single Step can advance, so one call can't keep the system busy for long
+const MaxStepTicks = 10000
+
+/**
+ *	Builds the gRPC server of an elevator control system. The system is safe for concurrent use, so the server
+	can serve many calls at once
+	@ control lift.ElevatorControlSystem
+*/
+func NewServer(control lift.ElevatorControlSystem) liftpb.ElevatorControlSystemServer {
+	return &server{control: control}
+}
+
+type server struct {
+	liftpb.UnimplementedElevatorControlSystemServer
+	control lift.ElevatorControlSystem
+}
+
+func (server *server) PickUp(ctx context.Context, request *liftpb.PickUpRequest) (*liftpb.PickUpResponse, error) {
+	assignment, err := server.control.PickUpButtonWasPushed(request.UserId, int(request.PickUpFloor), int(request.DropOffFloor))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &liftpb.PickUpResponse{ElevatorId: int32(assignment.ElevatorID), Eta: int32(assignment.ETA)}, nil
+}
+
+func (server *server) Update(ctx context.Context, request *liftpb.UpdateRequest) (*liftpb.CarStatus, error) {
+	if err := server.control.Update(int(request.ElevatorId), int(request.Floor), request.Direction); err != nil {
+		return nil, statusError(err)
+	}
+	return systemStatus(server.control.Snapshot()).Elevators[request.ElevatorId], nil
+}
+
+/**
+ *	Advances the simulation by the time units of the request, up to MaxStepTicks, or until no elevator has pending
+	trips and there are no more scheduled pick-up requests, like Step, without printing the step list. The elevators
+	stop moving once the client cancels the call
+*/
+func (server *server) Step(ctx context.Context, request *liftpb.StepRequest) (*liftpb.SystemStatus, error) {
+	if request.Ticks < 0 || request.Ticks > MaxStepTicks {
+		return nil, status.Errorf(codes.InvalidArgument, "can't advance %d time units, only between 0 and %d",
+			request.Ticks, MaxStepTicks)
+	}
+
+	var err error
+	if request.Ticks > 0 {
+		for i := 0; i < int(request.Ticks) && err == nil; i++ {
+			if err = ctx.Err(); err == nil {
+				err = server.control.Tick()
+			}
+		}
+	} else {
+		err = server.control.RunUntilIdle(ctx)
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return systemStatus(server.control.Snapshot()), nil
+}
+
+func (server *server) Status(ctx context.Context, request *liftpb.StatusRequest) (*liftpb.SystemStatus, error) {
+	return systemStatus(server.control.Snapshot()), nil
+}
+
+/**
+ *	Streams the events of the kinds of the request until the client cancels the call. The headers of the stream are
+	sent once the events are watched, so the client can wait for them before stepping the system. The oldest events
+	are dropped when the client doesn't read them as fast as they are published
+*/
+func (server *server) WatchEvents(request *liftpb.WatchEventsRequest, stream grpc.ServerStreamingServer[liftpb.Event]) error {
+	kinds := []lift.EventKind{}
+	for _, name := range request.Kinds {
+		kind, err := lift.ParseEventKind(name)
+		if err != nil {
+			return statusError(err)
+		}
+		kinds = append(kinds, kind)
+	}
+	subscription := server.control.SubscribeChannel(watchBuffer, lift.DropOldest, kinds...)
+	defer subscription.Cancel()
+	// The headers tell the client that no event published from now on will be missed
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case event := <-subscription.C:
+			if err := stream.Send(eventMessage(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+/***** GRPC SERVER HELPER FUNCTIONS *************/
+
+// The status telling why the elevator control system rejected a call
+func statusError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	code := codes.Internal
+	switch {
+	case errors.Is(err, lift.ErrUnknownElevator):
+		code = codes.NotFound
+	case errors.Is(err, lift.ErrInvalidStateChange), errors.Is(err, lift.ErrAlreadyRunning):
+		code = codes.FailedPrecondition
+	case errors.Is(err, lift.ErrFloorOutOfRange), errors.Is(err, lift.ErrSameFloor),
+		errors.Is(err, lift.ErrInvalidDirection), errors.Is(err, lift.ErrUnknownEventKind):
+		code = codes.InvalidArgument
+	}
+	return status.Error(code, err.Error())
+}
+
+func systemStatus(snapshot lift.SystemStatus) *liftpb.SystemStatus {
+	message := &liftpb.SystemStatus{Time: int32(snapshot.Time), TopFloor: int32(snapshot.TopFloor)}
+	for _, record := range lift.CarRecords(snapshot) {
+		car := &liftpb.CarStatus{
+			ElevatorId: int32(record.Elevator),
+			Floor:      int32(record.Floor),
+			Direction:  string(record.Direction),
+			State:      record.State,
+			Persons:    int32(record.Persons),
+			Load:       int32(record.Load),
+			Failures:   record.Failures,
+		}
+		for _, trip := range record.Trips {
+			car.Trips = append(car.Trips, &liftpb.Trip{
+				UserId:    trip.User,
+				Call:      trip.Call,
+				Action:    trip.Action,
+				FromFloor: int32(trip.From),
+				ToFloor:   int32(trip.To),
+			})
+		}
+		message.Elevators = append(message.Elevators, car)
+	}
+	for _, request := range snapshot.Waiting {
+		message.Waiting = append(message.Waiting, &liftpb.WaitingRequest{
+			At:           int32(request.At),
+			UserId:       request.UserID,
+			PickUpFloor:  int32(request.PickUpFloor),
+			DropOffFloor: int32(request.DropOffFloor),
+		})
+	}
+	return message
+}
+
+// The message of an event. The events of the elevators have no call
+func eventMessage(event lift.Event) *liftpb.Event {
+	message := &liftpb.Event{
+		Kind:       event.Kind.String(),
+		Time:       int32(event.Time),
+		ElevatorId: int32(event.ElevatorID),
+		Floor:      int32(event.Floor),
+		Direction:  string(event.Direction),
+		UserId:     event.UserID,
+		FromFloor:  int32(event.FromFloor),
+		ToFloor:    int32(event.ToFloor),
+	}
+	if event.UserID != "" {
+		message.Call = event.Call.String()
+	}
+	return message
+}
+
+/***** END OF GRPC SERVER HELPER FUNCTIONS *************/
@@ -0,0 +1,157 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+	"github.com/ArturoTarinVillaescusa/lift-go/liftpb"
+)
+
+// Serves the elevator control system in process, and tells the client connected to it
+// Builds the elevator control system of a test, which must not be rejected
+func newSystem(t testing.TB, numberOfElevators int, numberOfFloors int, options ...lift.Option) lift.ElevatorControlSystem {
+	t.Helper()
+	control, err := lift.NewElevatorControlSystem(numberOfElevators, numberOfFloors, options...)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return control
+}
+
+func newClient(t *testing.T, control lift.ElevatorControlSystem) liftpb.ElevatorControlSystemClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	liftpb.RegisterElevatorControlSystemServer(server, NewServer(control))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { connection.Close() })
+	return liftpb.NewElevatorControlSystemClient(connection)
+}
+
+func TestCallsAreAnsweredWithTheirStatusCodes(t *testing.T) {
+	client := newClient(t, newSystem(t, 2, 10))
+	ctx := context.Background()
+
+	var tests = []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"pick-up", func() error {
+			_, err := client.PickUp(ctx, &liftpb.PickUpRequest{UserId: "User1", PickUpFloor: 0, DropOffFloor: 5})
+			return err
+		}, codes.OK},
+		{"pick-up to the same floor", func() error {
+			_, err := client.PickUp(ctx, &liftpb.PickUpRequest{UserId: "User2", PickUpFloor: 3, DropOffFloor: 3})
+			return err
+		}, codes.InvalidArgument},
+		{"update", func() error {
+			_, err := client.Update(ctx, &liftpb.UpdateRequest{ElevatorId: 1, Floor: 7, Direction: "DOWN"})
+			return err
+		}, codes.OK},
+		{"update to a wrong direction", func() error {
+			_, err := client.Update(ctx, &liftpb.UpdateRequest{ElevatorId: 1, Floor: 7, Direction: "SIDEWAYS"})
+			return err
+		}, codes.InvalidArgument},
+		{"update of an unknown elevator", func() error {
+			_, err := client.Update(ctx, &liftpb.UpdateRequest{ElevatorId: 9, Floor: 7, Direction: "UP"})
+			return err
+		}, codes.NotFound},
+		{"step back in time", func() error {
+			_, err := client.Step(ctx, &liftpb.StepRequest{Ticks: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"step too far", func() error {
+			_, err := client.Step(ctx, &liftpb.StepRequest{Ticks: MaxStepTicks + 1})
+			return err
+		}, codes.InvalidArgument},
+		{"step", func() error {
+			_, err := client.Step(ctx, &liftpb.StepRequest{Ticks: 1})
+			return err
+		}, codes.OK},
+		{"update of an elevator opening its doors", func() error {
+			_, err := client.Update(ctx, &liftpb.UpdateRequest{ElevatorId: 0, Floor: 7, Direction: "UP"})
+			return err
+		}, codes.FailedPrecondition},
+		{"watch unknown events", func() error {
+			stream, err := client.WatchEvents(ctx, &liftpb.WatchEventsRequest{Kinds: []string{"LANDED"}})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		if code := status.Code(test.call()); code != test.code {
+			t.Errorf("%v: expected %v, got %v", test.name, test.code, code)
+		}
+	}
+}
+
+func TestStepStopsOnceTheCallIsCancelled(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 0, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewServer(control).Step(ctx, &liftpb.StepRequest{Ticks: MaxStepTicks})
+	if status.Code(err) != codes.Canceled || control.Now() != 0 {
+		t.Errorf("expected the elevators not to move once the call is cancelled, got %v at t=%d", err, control.Now())
+	}
+}
+
+func TestTheUsersArriveWhileTheEventsAreWatched(t *testing.T) {
+	client := newClient(t, newSystem(t, 2, 10))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchEvents(ctx, &liftpb.WatchEventsRequest{Kinds: []string{"BOARDED", "ALIGHTED"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Header(); err != nil {
+		t.Fatal(err)
+	}
+
+	pickUp, err := client.PickUp(ctx, &liftpb.PickUpRequest{UserId: "User1", PickUpFloor: 2, DropOffFloor: 6})
+	if err != nil || pickUp.ElevatorId != 0 {
+		t.Fatalf("expected the elevator 0 to take User1, got %v %v", pickUp, err)
+	}
+	status, err := client.Step(ctx, &liftpb.StepRequest{})
+	if err != nil || status.Time == 0 || len(status.Elevators[0].Trips) != 0 || status.Elevators[0].Floor != 6 {
+		t.Fatalf("expected User1 to arrive to the floor 6, got %v %v", status, err)
+	}
+
+	want := []struct {
+		kind  string
+		floor int32
+	}{
+		{"BOARDED", 2}, {"ALIGHTED", 6},
+	}
+	for i := range want {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.Kind != want[i].kind || event.Floor != want[i].floor || event.UserId != "User1" || event.Call != "PASSENGER" {
+			t.Errorf("event %d: expected User1 %v in floor %d, got %v", i, want[i].kind, want[i].floor, event)
+		}
+	}
+}
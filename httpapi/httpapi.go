@@ -0,0 +1,255 @@
+/*
+Package httpapi serves an elevator control system as a REST API with JSON bodies.
+
+	control, err := lift.NewElevatorControlSystem(16, 10)
+	if err != nil {
+		log.Fatal(err)
+	}
+	http.ListenAndServe(":8080", httpapi.NewHandler(control))
+
+These are the endpoints:
+
+	POST /pickups          registers a pick-up request, like PickUpButtonWasPushed
+	GET  /status           tells the state of every elevator, like Status
+	GET  /status/stream    streams the changes of the elevators as Server-Sent Events
+	PUT  /elevators/{id}   applies a maintenance override to an elevator, like Update
+	POST /step             advances the simulation, like Step
+*/
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               REST API                                          ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Body of POST /pickups: a user in a floor wants to go to another one
+type PickUp struct {
+	User string `json:"user"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Response of POST /pickups: the elevator that will take the user, and when, or -1 if the user has to wait
+type PickUpResponse struct {
+	Elevator int `json:"elevator"`
+	ETA      int `json:"eta"`
+}
+
+// Body of PUT /elevators/{id}: where the engineer leaves the elevator, and its direction
+type Override struct {
+	Floor     int    `json:"floor"`
+	Direction string `json:"direction"`
+}
+
+// Body of POST /step, which is optional: how many time units to advance, or until the elevators are idle if it's 0
+type StepRequest struct {
+	Ticks int `json:"ticks"` // Between 0 and MaxStepTicks
+}
+
+// Most time units a single POST /step can advance, so one request can't keep the system busy for long
+const MaxStepTicks = 10000
+
+// Response of GET /status and POST /step: the state of every elevator, and the pick-up requests waiting for one
+type StatusResponse struct {
+	Time      int              `json:"time"`
+	TopFloor  int              `json:"top_floor"`
+	Elevators []lift.CarRecord `json:"elevators"`
+	Waiting   []WaitingRecord  `json:"waiting"`
+}
+
+// A pick-up request waiting to be assigned to an elevator
+type WaitingRecord struct {
+	At   int    `json:"at"`
+	User string `json:"user"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Response of every request that fails, with the status code telling why
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+/**
+ *	Builds the handler of the REST API of an elevator control system. The system is safe for concurrent use,
+	so the handler can serve many requests at once
+	@ control lift.ElevatorControlSystem
+*/
+func NewHandler(control lift.ElevatorControlSystem) http.Handler {
+	api := &api{control: control}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /pickups", api.pickUp)
+	mux.HandleFunc("GET /status", api.status)
+	mux.HandleFunc("GET /status/stream", api.stream)
+	mux.HandleFunc("PUT /elevators/{id}", api.update)
+	mux.HandleFunc("POST /step", api.step)
+	return mux
+}
+
+/***** REST API HELPER FUNCTIONS *************/
+
+type api struct {
+	control lift.ElevatorControlSystem
+}
+
+// Registers a pick-up request. The user is assigned an elevator, 201, or waits for one with room for him, 202
+func (api *api) pickUp(w http.ResponseWriter, r *http.Request) {
+	var pickUp PickUp
+	if err := decode(r, &pickUp, false); err != nil {
+		writeError(w, err)
+		return
+	}
+	assignment, err := api.control.PickUpButtonWasPushed(pickUp.User, pickUp.From, pickUp.To)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	code := http.StatusCreated
+	if assignment.ElevatorID == lift.NoElevator {
+		code = http.StatusAccepted
+	}
+	writeJSON(w, code, PickUpResponse{Elevator: assignment.ElevatorID, ETA: assignment.ETA})
+}
+
+func (api *api) status(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse(api.control.Snapshot()))
+}
+
+// Applies a maintenance override, and tells the state of the elevator afterwards
+func (api *api) update(w http.ResponseWriter, r *http.Request) {
+	elevatorID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: elevator %q", errBadRequest, r.PathValue("id")))
+		return
+	}
+	var override Override
+	if err := decode(r, &override, false); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := api.control.Update(elevatorID, override.Floor, override.Direction); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lift.CarRecords(api.control.Snapshot())[elevatorID])
+}
+
+/**
+ *	Advances the simulation by the time units of the body, or until no elevator has pending trips and there are
+	no more scheduled pick-up requests, like Step, without printing the step list
+*/
+func (api *api) step(w http.ResponseWriter, r *http.Request) {
+	var step StepRequest
+	if err := decode(r, &step, true); err != nil {
+		writeError(w, err)
+		return
+	}
+	if step.Ticks < 0 || step.Ticks > MaxStepTicks {
+		writeError(w, fmt.Errorf("%w: can't advance %d time units, only between 0 and %d", errBadRequest, step.Ticks, MaxStepTicks))
+		return
+	}
+
+	// The elevators stop moving once the client is gone
+	ctx := r.Context()
+	var err error
+	if step.Ticks > 0 {
+		for i := 0; i < step.Ticks && err == nil; i++ {
+			if err = ctx.Err(); err == nil {
+				err = api.control.Tick()
+			}
+		}
+	} else {
+		err = api.control.RunUntilIdle(ctx)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statusResponse(api.control.Snapshot()))
+}
+
+func statusResponse(status lift.SystemStatus) StatusResponse {
+	response := StatusResponse{
+		Time:      status.Time,
+		TopFloor:  status.TopFloor,
+		Elevators: lift.CarRecords(status),
+		Waiting:   []WaitingRecord{},
+	}
+	for _, request := range status.Waiting {
+		response.Waiting = append(response.Waiting, WaitingRecord{
+			At:   request.At,
+			User: request.UserID,
+			From: request.PickUpFloor,
+			To:   request.DropOffFloor,
+		})
+	}
+	return response
+}
+
+// Error of a request the API can't understand
+var errBadRequest = errors.New("bad request")
+
+/**
+ *	Decodes the JSON body of the request, rejecting the unknown fields. An empty body is rejected too,
+	unless it is optional
+	@ r *http.Request
+	@ body any
+	@ optional bool
+*/
+func decode(r *http.Request, body any, optional bool) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(body)
+	if errors.Is(err, io.EOF) && optional {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+// The status code telling why the elevator control system rejected a request
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, lift.ErrUnknownElevator):
+		return http.StatusNotFound
+	case errors.Is(err, lift.ErrInvalidStateChange), errors.Is(err, lift.ErrAlreadyRunning):
+		return http.StatusConflict
+	case errors.Is(err, lift.ErrFloorOutOfRange), errors.Is(err, lift.ErrSameFloor), errors.Is(err, lift.ErrInvalidDirection):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusCode(err), ErrorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+/***** END OF REST API HELPER FUNCTIONS *************/
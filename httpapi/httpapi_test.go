@@ -0,0 +1,112 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+// Builds the elevator control system of a test, which must not be rejected
+func newSystem(t testing.TB, numberOfElevators int, numberOfFloors int, options ...lift.Option) lift.ElevatorControlSystem {
+	t.Helper()
+	control, err := lift.NewElevatorControlSystem(numberOfElevators, numberOfFloors, options...)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return control
+}
+
+func TestRequestsAreAnsweredWithTheirStatusCodes(t *testing.T) {
+	var tests = []struct {
+		method    string
+		path      string
+		body      string
+		code      int
+		errorBody bool
+	}{
+		{"POST", "/pickups", `{"user": "User1", "from": 0, "to": 5}`, http.StatusCreated, false},
+		{"POST", "/pickups", `{"user": "User2", "from": 3, "to": 3}`, http.StatusUnprocessableEntity, true},
+		{"POST", "/pickups", `{"user": "User3", "from": 0, "to": 11}`, http.StatusUnprocessableEntity, true},
+		{"POST", "/pickups", `{"user": "User4", "from": 0`, http.StatusBadRequest, true},
+		{"POST", "/pickups", `{"user": "User5", "from": 0, "to": 5, "weight": 80}`, http.StatusBadRequest, true},
+		{"GET", "/pickups", ``, http.StatusMethodNotAllowed, false},
+		{"GET", "/status", ``, http.StatusOK, false},
+		{"PUT", "/elevators/1", `{"floor": 7, "direction": "DOWN"}`, http.StatusOK, false},
+		{"PUT", "/elevators/1", `{"floor": 7, "direction": "SIDEWAYS"}`, http.StatusUnprocessableEntity, true},
+		{"PUT", "/elevators/1", `{"floor": 12, "direction": "UP"}`, http.StatusUnprocessableEntity, true},
+		{"PUT", "/elevators/9", `{"floor": 7, "direction": "UP"}`, http.StatusNotFound, true},
+		{"PUT", "/elevators/first", `{"floor": 7, "direction": "UP"}`, http.StatusBadRequest, true},
+		{"POST", "/step", `{"ticks": -1}`, http.StatusBadRequest, true},
+		{"POST", "/step", `{"ticks": 10001}`, http.StatusBadRequest, true},
+		{"POST", "/step", `{"ticks": 1}`, http.StatusOK, false},
+		{"PUT", "/elevators/0", `{"floor": 7, "direction": "UP"}`, http.StatusConflict, true},
+		{"POST", "/step", ``, http.StatusOK, false},
+		{"GET", "/elevators", ``, http.StatusNotFound, false},
+	}
+
+	handler := NewHandler(newSystem(t, 2, 10))
+	for _, test := range tests {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+		if response.Code != test.code {
+			t.Errorf("%v %v %v: expected %d, got %d %v", test.method, test.path, test.body, test.code, response.Code, response.Body)
+		}
+		if test.errorBody {
+			var failure ErrorResponse
+			if err := json.NewDecoder(response.Body).Decode(&failure); err != nil || failure.Error == "" {
+				t.Errorf("%v %v %v: expected an error in the body, got %v", test.method, test.path, test.body, err)
+			}
+		}
+	}
+}
+
+func TestThePickUpsAreSteppedUntilTheUsersArrive(t *testing.T) {
+	handler := NewHandler(newSystem(t, 1, 10, lift.WithCapacity(1, 75)))
+	do := func(method string, path string, body string, response any) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+		if err := json.NewDecoder(recorder.Body).Decode(response); err != nil {
+			t.Fatalf("%v %v: %v", method, path, err)
+		}
+	}
+
+	var first, second PickUpResponse
+	do("POST", "/pickups", `{"user": "User1", "from": 0, "to": 5}`, &first)
+	do("POST", "/pickups", `{"user": "User2", "from": 2, "to": 4}`, &second)
+	if first.Elevator != 0 || second.Elevator != -1 || second.ETA != -1 {
+		t.Errorf("expected User1 to take the elevator and User2 to wait for it, got %+v and %+v", first, second)
+	}
+
+	var status StatusResponse
+	do("GET", "/status", ``, &status)
+	if len(status.Elevators) != 1 || len(status.Elevators[0].Trips) != 1 || len(status.Waiting) != 1 ||
+		status.Waiting[0].User != "User2" || status.Waiting[0].From != 2 || status.Waiting[0].To != 4 {
+		t.Errorf("expected User1 in the elevator and User2 waiting, got %+v", status)
+	}
+
+	do("POST", "/step", ``, &status)
+	if status.Time == 0 || len(status.Elevators[0].Trips) != 0 || len(status.Waiting) != 0 {
+		t.Errorf("expected both users to have arrived, got %+v", status)
+	}
+}
+
+func TestStepStopsOnceTheClientIsGone(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 0, 10)
+	handler := NewHandler(control)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, body := range []string{``, `{"ticks": 5}`} {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest("POST", "/step", strings.NewReader(body)).WithContext(ctx))
+		if response.Code != http.StatusServiceUnavailable || control.Now() != 0 {
+			t.Errorf("%q: expected the elevators not to move for a client that is gone, got %d at t=%d", body, response.Code, control.Now())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               LIVE STATUS STREAMING                             ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Events of the elevators a streaming client can fall behind before it is sent a whole snapshot again
+const streamBuffer = 64
+
+/**
+ *	Streams the state of the elevators as Server-Sent Events. The client gets a "snapshot" event on connect, with
+	a StatusResponse, and then a "car" event with a lift.StepRecord for every change of an elevator while the system
+	is stepped: CAR_ARRIVED when it arrives to a floor, DOORS_OPENED and DOORS_CLOSED, and the events of its trips,
+	like ASSIGNED, BOARDED or ALIGHTED. A client too slow to read every change gets a new snapshot instead of the
+	changes it missed
+*/
+func (api *api) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, fmt.Errorf("streaming is not supported by the connection"))
+		return
+	}
+	// Subscribing before the snapshot is taken, no change is missed between them
+	subscription := api.control.SubscribeChannel(streamBuffer, lift.DropOldest)
+	defer subscription.Cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	dropped := 0
+	since, err := writeSnapshot(w, api.control)
+	for err == nil {
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-subscription.C:
+			if subscription.Dropped() > dropped {
+				dropped = subscription.Dropped()
+				since, err = writeSnapshot(w, api.control)
+				continue
+			}
+			// The changes that happened before the snapshot are already in it
+			if event.Time >= since {
+				err = writeEvent(w, "car", eventRecord(event))
+			}
+		}
+	}
+}
+
+/***** LIVE STATUS STREAMING HELPER FUNCTIONS *************/
+
+// Writes a snapshot of the system, and tells its time
+func writeSnapshot(w http.ResponseWriter, control lift.ElevatorControlSystem) (int, error) {
+	status := control.Snapshot()
+	return status.Time, writeEvent(w, "snapshot", statusResponse(status))
+}
+
+// The change of an elevator, with the same fields of the steps of its step list. The events of the elevator have no call
+func eventRecord(event lift.Event) lift.StepRecord {
+	record := lift.StepRecord{
+		Time:      event.Time,
+		Elevator:  event.ElevatorID,
+		Floor:     event.Floor,
+		Direction: event.Direction,
+		User:      event.UserID,
+		Action:    event.Kind.String(),
+		From:      event.FromFloor,
+		To:        event.ToFloor,
+		CalledAt:  event.CalledAt,
+	}
+	if event.UserID != "" {
+		record.Call = event.Call.String()
+	}
+	return record
+}
+
+// Writes a Server-Sent Event with a JSON body
+func writeEvent(w http.ResponseWriter, event string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+/***** END OF LIVE STATUS STREAMING HELPER FUNCTIONS *************/
@@ -0,0 +1,89 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+func TestTheChangesOfTheCarsAreStreamedAfterASnapshot(t *testing.T) {
+	server := httptest.NewServer(NewHandler(newSystem(t, 2, 10)))
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/status/stream", nil)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected a stream of events, got %v", response.Header.Get("Content-Type"))
+	}
+	events := bufio.NewScanner(response.Body)
+	next := func() (string, string) {
+		event, data := "", ""
+		for events.Scan() && events.Text() != "" {
+			if name, found := strings.CutPrefix(events.Text(), "event: "); found {
+				event = name
+			}
+			if body, found := strings.CutPrefix(events.Text(), "data: "); found {
+				data = body
+			}
+		}
+		return event, data
+	}
+
+	event, data := next()
+	var status StatusResponse
+	if err := json.Unmarshal([]byte(data), &status); event != "snapshot" || err != nil || len(status.Elevators) != 2 {
+		t.Fatalf("expected a snapshot of the 2 elevators on connect, got %v %v", event, data)
+	}
+
+	http.Post(server.URL+"/pickups", "application/json", strings.NewReader(`{"user": "User1", "from": 0, "to": 3}`))
+	http.Post(server.URL+"/step", "application/json", nil)
+
+	want := []struct {
+		action string
+		floor  int
+	}{
+		{"ASSIGNED", 0}, {"DOORS_OPENED", 0}, {"BOARDED", 0}, {"DOORS_CLOSED", 0}, {"CAR_ARRIVED", 1},
+		{"CAR_ARRIVED", 2}, {"CAR_ARRIVED", 3}, {"DOORS_OPENED", 3}, {"ALIGHTED", 3},
+	}
+	for i := range want {
+		event, data := next()
+		var car lift.StepRecord
+		if err := json.Unmarshal([]byte(data), &car); event != "car" || err != nil {
+			t.Fatalf("expected the changes of the cars, got %v %v", event, data)
+		}
+		if car.Elevator != 0 || car.Action != want[i].action || car.Floor != want[i].floor {
+			t.Errorf("change %d: expected %v in floor %d of the elevator 0, got %+v", i, want[i].action, want[i].floor, car)
+		}
+	}
+}
+
+func TestTheEventsOfTheElevatorsHaveNoCall(t *testing.T) {
+	var tests = []struct {
+		event lift.Event
+		call  string
+	}{
+		{lift.Event{Kind: lift.CarArrived, Floor: 2, FromFloor: lift.UnknownFloor, ToFloor: lift.UnknownFloor}, ""},
+		{lift.Event{Kind: lift.DoorsOpened, Floor: 2, FromFloor: lift.UnknownFloor, ToFloor: lift.UnknownFloor}, ""},
+		{lift.Event{Kind: lift.Boarded, Floor: 2, UserID: "User1", Call: lift.PassengerCall, FromFloor: 2, ToFloor: 5, CalledAt: 1}, "PASSENGER"},
+		{lift.Event{Kind: lift.HallCallRegistered, Floor: 4, UserID: "Hall call UP", Call: lift.HallButtonCall, FromFloor: 2, ToFloor: lift.UnknownFloor}, "HALL"},
+	}
+	for _, test := range tests {
+		record := eventRecord(test.event)
+		if record.Call != test.call || record.Action != test.event.Kind.String() || record.Floor != test.event.Floor ||
+			record.CalledAt != test.event.CalledAt {
+			t.Errorf("%+v: expected a record with call %q, got %+v", test.event, test.call, record)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package lift
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               EVENT BUS                                         ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Something that happened to an elevator or to a trip, published to the subscribers of the system
+type Event struct {
+	Kind       EventKind
+	Time       int       // Simulated time when it happened
+	ElevatorID int       // Elevator where it happened
+	Floor      int       // Floor of the elevator
+	Direction  Direction // Direction of the elevator
+	UserID     string    // User of the trip, empty for the events of the elevator
+	TripID     int       // Trip the event belongs to, 0 for the events of the elevator
+	CalledAt   int       // Simulated time when the user called the elevator, 0 for the events of the elevator
+	Call       CallKind  // Kind of call of the trip
+	FromFloor  int       // Pick-up floor of the trip, UnknownFloor for the events of the elevator
+	ToFloor    int       // Drop-off floor of the trip, UnknownFloor for the events of the elevator and the hall calls
+}
+
+// What a channel subscription does with an event when its channel is full
+type Backpressure int
+
+const (
+	// Waits until the subscriber reads from the channel, so the simulation goes at the pace of the slowest subscriber
+	Block Backpressure = iota
+	// Drops the event that doesn't fit in the channel
+	DropNewest
+	// Drops the oldest event in the channel to make room for the new one
+	DropOldest
+)
+
+/**
+ *	A subscriber of the events of the system. Channel subscriptions receive the events in C, and callback
+	subscriptions have a nil C
+*/
+type Subscription struct {
+	C <-chan Event
+
+	events       chan Event
+	callback     func(Event)
+	kinds        map[EventKind]bool // Events the subscriber wants, all of them if it's empty
+	backpressure Backpressure
+	bus          *eventBus
+	dropped      int64
+	done         chan struct{} // Closed when the subscription is cancelled
+	cancelled    sync.Once
+	sending      sync.Mutex // Held while an event is sent to the channel, so it isn't closed meanwhile
+}
+
+// Events dropped because the channel of the subscription was full
+func (subscription *Subscription) Dropped() int {
+	return int(atomic.LoadInt64(&subscription.dropped))
+}
+
+// Stops sending events to the subscriber, closing its channel. It can be called more than once
+func (subscription *Subscription) Cancel() {
+	subscription.bus.unsubscribe(subscription)
+	subscription.cancelled.Do(func() {
+		close(subscription.done)
+		subscription.sending.Lock()
+		defer subscription.sending.Unlock()
+		if subscription.events != nil {
+			close(subscription.events)
+		}
+	})
+}
+
+/**
+ *	Calls the callback with every event of these kinds, or of all of them if no kind is given. The callback is
+	called once the elevators have moved, out of the lock of the system, so it can call the system back, even to
+	move the elevators with Tick, Advance or Step: the events of those ticks are published once the callback
+	returns, after the events of the tick being published. The next tick of the other callers waits for it, so it
+	should return quickly
+	@ callback func(Event)
+	@ kinds ...EventKind
+*/
+func (control *elevatorControlSystem) Subscribe(callback func(Event), kinds ...EventKind) *Subscription {
+	return control.bus.subscribe(&Subscription{callback: callback, kinds: kindSet(kinds)})
+}
+
+/**
+ *	Sends every event of these kinds, or of all of them if no kind is given, to a channel with room for size events.
+	The backpressure tells what to do when the subscriber doesn't read the channel as fast as the events are published
+	@ size int
+	@ backpressure Backpressure
+	@ kinds ...EventKind
+*/
+func (control *elevatorControlSystem) SubscribeChannel(size int, backpressure Backpressure, kinds ...EventKind) *Subscription {
+	events := make(chan Event, size)
+	return control.bus.subscribe(&Subscription{C: events, events: events, backpressure: backpressure, kinds: kindSet(kinds)})
+}
+
+/***** EVENT BUS HELPER FUNCTIONS *************/
+
+// Subscribers of the events of the system, and the events not published to them yet
+type eventBus struct {
+	mutex         sync.Mutex
+	subscriptions []*Subscription
+	queue         []Event    // Events waiting to be published, in the order the elevators made them
+	delivering    bool       // Tells if some caller is publishing the queue
+	inCallback    bool       // Tells if the queue is being published to a callback, which can call the system back
+	delivered     *sync.Cond // Signalled once the queue has been published
+}
+
+func (bus *eventBus) subscribe(subscription *Subscription) *Subscription {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	subscription.bus = bus
+	subscription.done = make(chan struct{})
+	bus.subscriptions = append(bus.subscriptions, subscription)
+	return subscription
+}
+
+func (bus *eventBus) unsubscribe(subscription *Subscription) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	for i := range bus.subscriptions {
+		if bus.subscriptions[i] == subscription {
+			bus.subscriptions = append(bus.subscriptions[:i], bus.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Queues the events of a tick, so they are published in the order of the ticks
+func (bus *eventBus) enqueue(events []Event) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.queue = append(bus.queue, events...)
+}
+
+/**
+ *	Publishes the queued events, and returns once they have been published. If another caller is already publishing
+	them, it waits for it, unless a callback is running: the callback could be the one calling the system back, and
+	waiting for itself would be a deadlock, so its events are published by that caller once the callback returns
+*/
+func (bus *eventBus) deliver() {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	if bus.delivered == nil {
+		bus.delivered = sync.NewCond(&bus.mutex)
+	}
+	if bus.inCallback {
+		return
+	}
+	for bus.delivering {
+		bus.delivered.Wait()
+	}
+
+	bus.delivering = true
+	for len(bus.queue) > 0 {
+		event := bus.queue[0]
+		bus.queue = bus.queue[1:]
+		bus.mutex.Unlock()
+		bus.publish(event)
+		bus.mutex.Lock()
+	}
+	bus.delivering = false
+	bus.delivered.Broadcast()
+}
+
+// Sends the event to every subscriber that wants it, in the order they subscribed
+func (bus *eventBus) publish(event Event) {
+	bus.mutex.Lock()
+	subscriptions := append([]*Subscription{}, bus.subscriptions...)
+	bus.mutex.Unlock()
+
+	for _, subscription := range subscriptions {
+		if len(subscription.kinds) > 0 && !subscription.kinds[event.Kind] {
+			continue
+		}
+		if subscription.callback != nil {
+			bus.callback(subscription, event)
+			continue
+		}
+		subscription.send(event)
+	}
+}
+
+// Calls the callback of the subscription, telling the callers of deliver that the system can be called back meanwhile
+func (bus *eventBus) callback(subscription *Subscription, event Event) {
+	bus.mutex.Lock()
+	bus.inCallback = true
+	bus.mutex.Unlock()
+	defer func() {
+		bus.mutex.Lock()
+		bus.inCallback = false
+		bus.mutex.Unlock()
+	}()
+	subscription.callback(event)
+}
+
+// Sends the event to the channel of the subscription, applying its backpressure when the channel is full
+func (subscription *Subscription) send(event Event) {
+	subscription.sending.Lock()
+	defer subscription.sending.Unlock()
+
+	select {
+	case <-subscription.done:
+		return
+	default:
+	}
+
+	switch subscription.backpressure {
+	case Block:
+		select {
+		case subscription.events <- event:
+		case <-subscription.done:
+		}
+	case DropNewest:
+		select {
+		case subscription.events <- event:
+		default:
+			atomic.AddInt64(&subscription.dropped, 1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case subscription.events <- event:
+				return
+			default:
+			}
+			// A channel without room for any event can only drop the new one
+			select {
+			case <-subscription.events:
+				atomic.AddInt64(&subscription.dropped, 1)
+			default:
+				atomic.AddInt64(&subscription.dropped, 1)
+				return
+			}
+		}
+	}
+}
+
+// Set of the kinds of events a subscriber wants
+func kindSet(kinds []EventKind) map[EventKind]bool {
+	set := map[EventKind]bool{}
+	for _, kind := range kinds {
+		set[kind] = true
+	}
+	return set
+}
+
+// The event of a step of a trip
+func stepEvent(elevatorID int, step TripDetails) Event {
+	return Event{
+		Kind:       step.Event,
+		Time:       step.Time,
+		ElevatorID: elevatorID,
+		Floor:      step.ElevatorFloor,
+		Direction:  step.ElevatorDirection,
+		UserID:     step.UserID,
+		TripID:     step.TripID,
+		CalledAt:   step.CalledAt,
+		Call:       step.Call,
+		FromFloor:  step.FromFloor,
+		ToFloor:    step.ToFloor,
+	}
+}
+
+/***** END OF EVENT BUS HELPER FUNCTIONS *************/
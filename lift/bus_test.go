@@ -0,0 +1,103 @@
+package lift
+
+import "testing"
+
+func TestSubscribersReceiveTheEventsOfTheElevators(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	events := []Event{}
+	control.Subscribe(func(event Event) { events = append(events, event) })
+	boarded := []Event{}
+	control.Subscribe(func(event Event) { boarded = append(boarded, event) }, Boarded)
+	control.PickUpButtonWasPushed("User1", 0, 2)
+	control.Step()
+
+	want := []struct {
+		kind  EventKind
+		time  int
+		floor int
+	}{
+		{Assigned, 0, 0}, {DoorsOpened, 1, 0}, {Boarded, 1, 0}, {DoorsClosed, 3, 0}, {CarArrived, 3, 1},
+		{CarArrived, 4, 2}, {DoorsOpened, 6, 2}, {Alighted, 6, 2},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i := range want {
+		if events[i].Kind != want[i].kind || events[i].Time != want[i].time || events[i].Floor != want[i].floor {
+			t.Errorf("event %d: expected %v at t=%d in floor %d, got %+v", i, want[i].kind, want[i].time, want[i].floor, events[i])
+		}
+	}
+	if len(boarded) != 1 || boarded[0].UserID != "User1" || boarded[0].FromFloor != 0 || boarded[0].ToFloor != 2 {
+		t.Errorf("expected only the event of User1 boarding, got %+v", boarded)
+	}
+}
+
+func TestEveryRepeatedCallPublishesItsEvents(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	trips := map[EventKind][]int{}
+	control.Subscribe(func(event Event) { trips[event.Kind] = append(trips[event.Kind], event.TripID) },
+		HallCallRegistered, HallCallAnswered, CarCallRegistered, CarCallServed)
+
+	for call := 0; call < 2; call++ {
+		control.HallCall(4, UP)
+		control.Step()
+		control.CarCall(0, 8)
+		control.Step()
+	}
+
+	for _, kind := range []EventKind{HallCallRegistered, HallCallAnswered, CarCallRegistered, CarCallServed} {
+		if len(trips[kind]) != 2 || trips[kind][0] == trips[kind][1] {
+			t.Errorf("expected 2 %v events of different trips, got the trips %v", kind, trips[kind])
+		}
+	}
+}
+
+func TestChannelSubscribersBackpressure(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	newest := control.SubscribeChannel(2, DropNewest)
+	oldest := control.SubscribeChannel(2, DropOldest)
+	control.PickUpButtonWasPushed("User1", 0, 2)
+	control.Step()
+
+	if newest.Dropped() != 6 || oldest.Dropped() != 6 {
+		t.Errorf("expected both subscriptions to drop 6 of the 8 events, got %d and %d", newest.Dropped(), oldest.Dropped())
+	}
+	if first := <-newest.C; first.Kind != Assigned {
+		t.Errorf("expected DropNewest to keep the first events, got %v", first.Kind)
+	}
+	if first := <-oldest.C; first.Kind != DoorsOpened {
+		t.Errorf("expected DropOldest to keep the last events, got %v", first.Kind)
+	}
+}
+
+func TestBlockingSubscriberReceivesEveryEventUntilItCancels(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	subscription := control.SubscribeChannel(0, Block, Alighted)
+	received := make(chan []Event)
+	go func() {
+		events := []Event{}
+		for event := range subscription.C {
+			events = append(events, event)
+		}
+		received <- events
+	}()
+	control.PickUpButtonWasPushed("User1", 0, 2)
+	control.PickUpButtonWasPushed("User2", 0, 3)
+	control.Step()
+	subscription.Cancel()
+	subscription.Cancel()
+
+	if events := <-received; len(events) != 2 || events[0].UserID != "User1" || events[1].UserID != "User2" {
+		t.Errorf("expected User1 and User2 to alight, got %+v", events)
+	}
+
+	// The cancelled subscribers don't receive more events
+	called := 0
+	callback := control.Subscribe(func(Event) { called++ })
+	callback.Cancel()
+	control.PickUpButtonWasPushed("User3", 3, 0)
+	control.Step()
+	if called != 0 {
+		t.Errorf("expected the cancelled callback not to be called, got %d calls", called)
+	}
+}
@@ -0,0 +1,234 @@
+package lift
+
+import (
+	"math"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***      DISPATCHER INTERFACE DEFINITION AND IMPLEMENTATIONS        ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// NoElevator is returned by a Dispatcher when none of the elevators can take the user right now
+const NoElevator = -1
+
+// UnknownETA is returned when it's not known when an elevator will pick-up a user
+const UnknownETA = -1
+
+/**
+ *	A Dispatcher chooses which elevator will take a user to the floor he wants to go. The Elevator Control System
+	uses the ProximityDispatcher unless another one is given to NewElevatorControlSystem with WithDispatcher.
+
+	Dispatch receives the elevators of the system and the user's request, and returns the position of the chosen
+	elevator in the list, or NoElevator if the user has to wait, i.e. because all the elevators are full. The user's
+	request will be dispatched again in the next tick.
+
+	The Assignment also tells the time units the chosen elevator needs to arrive to the pick-up floor, which can be
+	estimated with EstimateTimeToPickUp
+*/
+type Dispatcher interface {
+	Dispatch(elevators []Elevator, request PickUpRequest) Assignment
+}
+
+// Which elevator will take the user, and when it will arrive to the pick-up floor
+type Assignment struct {
+	ElevatorID int // NoElevator if the user has to wait for an elevator with room for him
+	ETA        int // Time units until the elevator picks-up the user, or UnknownETA
+}
+
+// A user's request to be taken from a floor to another one
+type PickUpRequest struct {
+	At           int    // Simulated time when the user pushes the pick-up button
+	UserID       string // Not necessary, but added for debugging and tracing purposes
+	PickUpFloor  int    // Floor where the user pushes the pick-up button
+	DropOffFloor int    // Floor where the user wants to go, UnknownFloor for the hall calls
+
+	HallCallDirection Direction // Button pushed by the user in a hall call: UP or DOWN
+
+	TripID int // Unique ID of the trip in the system, given by the system when the request is made
+}
+
+// Direction of the trip requested by the user
+func (request PickUpRequest) Direction() Direction {
+	if request.DropOffFloor == UnknownFloor {
+		return request.HallCallDirection
+	}
+	if request.PickUpFloor < request.DropOffFloor {
+		return UP
+	}
+	return DOWN
+}
+
+// The trip an elevator has to do to satisfy the request
+func (request PickUpRequest) trip() TripDetails {
+	trip := TripDetails{
+		TripID:        request.TripID,
+		UserID:        request.UserID,
+		Event:         Assigned,
+		FromFloor:     request.PickUpFloor,
+		ToFloor:       request.DropOffFloor,
+		TripDirection: request.Direction(),
+		Weight:        averageUserWeight,
+		CalledAt:      request.At,
+	}
+	if request.DropOffFloor == UnknownFloor {
+		trip.Call = HallButtonCall
+		trip.Event = HallCallRegistered
+	}
+	return trip
+}
+
+/**
+ *	Tells how many time units an elevator would need to pick-up the user, if the request was assigned to it now,
+	or UnknownETA if it wouldn't pick-up the user. The trips already assigned to the elevator are replayed with the
+	new one, taking into account the time to travel between the floors and to open and close the doors at every stop
+	@ elev Elevator
+	@ request PickUpRequest
+*/
+func EstimateTimeToPickUp(elev Elevator, request PickUpRequest) int {
+	return elev.EstimateTimeToPickUp(request.trip())
+}
+
+/**
+ *	Sets the Dispatcher choosing the elevator for every pick-up request
+	@ dispatcher Dispatcher
+*/
+func WithDispatcher(dispatcher Dispatcher) Option {
+	return func(control *elevatorControlSystem) {
+		control.dispatcher = dispatcher
+	}
+}
+
+// The default Dispatcher of the system, choosing the elevators with chooseTheMostOptimalElevator
+type ProximityDispatcher struct{}
+
+func (ProximityDispatcher) Dispatch(elevators []Elevator, request PickUpRequest) Assignment {
+	chosenElevator := chooseTheMostOptimalElevator(elevators, request)
+	if chosenElevator == NoElevator {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA}
+	}
+	return Assignment{ElevatorID: chosenElevator, ETA: EstimateTimeToPickUp(elevators[chosenElevator], request)}
+}
+
+/**
+ *	Dispatcher choosing the elevator that will arrive first to the user's pick-up floor, instead of the nearest one.
+	The arrival time of every elevator in service with room for the user is estimated with EstimateTimeToPickUp,
+	so the stops it has already committed to are taken into account
+*/
+type ETADispatcher struct{}
+
+func (ETADispatcher) Dispatch(elevators []Elevator, request PickUpRequest) Assignment {
+	best := Assignment{ElevatorID: NoElevator, ETA: UnknownETA}
+	for i := range elevators {
+		if !canTakeAnotherUser(elevators[i]) {
+			continue
+		}
+		eta := EstimateTimeToPickUp(elevators[i], request)
+		if eta != UnknownETA && (best.ETA == UnknownETA || eta < best.ETA) {
+			best = Assignment{ElevatorID: i, ETA: eta}
+		}
+	}
+	return best
+}
+
+/**
+ *
+   This Is the scheduler optimizer.
+
+   I've chosen this set of rules to assign the best elevator for every pick-up request:
+
+	- When a user pushes the PickUp button at any floor, the Elevator chosen to give him service will be elected attending to:
+
+		1) The elevatorProximity: the difference between the floor where the nearest elevator is and the user's pickup floor
+   		   This rule will be rejected in favour of the next one, in case it happens ...
+
+		   Only the elevators in service with room for the user, stopped or going in the same direction of the user's trip
+		   are taken into account. If none of them is going in that direction, the nearest elevator in service with room
+		   is chosen. If all the elevators in service are full, or none is in service, no elevator is chosen and the
+		   user has to wait
+
+		2) The elevator matching more than 7 in the value to 'elevatorWithMoreDropOffTripsMatchingTheRequestedDropOff', assuming
+   		   this rule forged only in my imagination: the most people going to the same plant the more savings in electricity (maybe
+   			it is not true in the real world, but ... I didn't want to leave just a FIFO queue of pickups and drop-offs)
+*/
+func chooseTheMostOptimalElevator(elevators []Elevator, request PickUpRequest) int {
+	var chosenElevator int = NoElevator
+	var nearestElevator int = 9999 // Forces the calculation of the nearest elevator
+	var elevatorWithMoreDropOffTripsMatchingTheRequestedDropOff int
+	var maxDropOffLoad int
+
+	// Direction of the trip requested by the user
+	tripDirection := request.Direction()
+
+	// Get the nearest elevator going in the same direction than the user wants to go
+	for i := range elevators {
+		elevatorProximity := int(math.Abs(float64(elevators[i].GetFloorNumber() - request.PickUpFloor)))
+		// Our optimal elevator to pick up is the nearest one
+		if elevatorProximity < nearestElevator && canGoInTheDirection(elevators[i], tripDirection) &&
+			elevators[i].HasRoomForAnotherTrip(averageUserWeight) {
+			chosenElevator = i
+			nearestElevator = elevatorProximity
+		}
+	}
+
+	// If no elevator can go in the user's direction, get the nearest one in service with room for the user
+	if chosenElevator == NoElevator {
+		for i := range elevators {
+			elevatorProximity := int(math.Abs(float64(elevators[i].GetFloorNumber() - request.PickUpFloor)))
+			if elevatorProximity < nearestElevator && canTakeAnotherUser(elevators[i]) {
+				chosenElevator = i
+				nearestElevator = elevatorProximity
+			}
+		}
+	}
+
+	// If all the elevators in service are full, or none of them is in service, the user keeps queued until one
+	// of them can take him
+	if chosenElevator == NoElevator {
+		return NoElevator
+	}
+
+	// Get the elevator carrying more amount of people wanting to go to the drop-off floor
+	for i := range elevators {
+		peopleGoingToTheSameDropOffFloor := 0
+
+		// Look at all the assigned trips of this elevator and count how much  people wants
+		// to stop in the same dropOffFloor than our user
+		for j := range elevators[i].GetAssignedTrips() {
+			if elevators[i].GetAssignedTrip(j).ToFloor == request.DropOffFloor {
+				peopleGoingToTheSameDropOffFloor++
+			}
+		}
+		// If there's an elevator going to our same plant and going in the same tripDirection of our current user,
+		// it will be chosen as a candidate to be choosen instead of the nearest one
+		if peopleGoingToTheSameDropOffFloor > maxDropOffLoad && canGoInTheDirection(elevators[i], tripDirection) &&
+			elevators[i].HasRoomForAnotherTrip(averageUserWeight) {
+			elevatorWithMoreDropOffTripsMatchingTheRequestedDropOff = i
+			maxDropOffLoad = peopleGoingToTheSameDropOffFloor
+		}
+	}
+
+	// If there's an elevator carrying more than 7 people in it, going to our same plant and going in the same direction of our current user,
+	// it will choose this one to assign the pick-up task instead of the nearest one
+	if maxDropOffLoad > 7 {
+		chosenElevator = elevatorWithMoreDropOffTripsMatchingTheRequestedDropOff
+	}
+
+	return chosenElevator
+}
+
+// Tells if an elevator is in service and has room for another user, so it can be chosen by the dispatchers
+func canTakeAnotherUser(elev Elevator) bool {
+	return elev.GetState() != OutOfService && elev.HasRoomForAnotherTrip(averageUserWeight)
+}
+
+// Tells if an elevator in service can take a user going in this direction
+func canGoInTheDirection(elev Elevator, direction Direction) bool {
+	if elev.GetState() == OutOfService {
+		return false
+	}
+	return elev.GetDirection() == direction || elev.GetDirection() == STOPPED
+}
@@ -0,0 +1,108 @@
+package lift
+
+import (
+	"errors"
+	"testing"
+)
+
+// Sends every user to the last elevator of the system, or makes them wait while it's closed
+type lastElevatorDispatcher struct {
+	closed bool
+}
+
+func (dispatcher *lastElevatorDispatcher) Dispatch(elevators []Elevator, request PickUpRequest) Assignment {
+	if dispatcher.closed {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA}
+	}
+	return Assignment{ElevatorID: len(elevators) - 1, ETA: UnknownETA}
+}
+
+func TestCustomDispatcher(t *testing.T) {
+	control := newSystem(t, 3, 10, WithDispatcher(&lastElevatorDispatcher{}))
+	control.PickUpButtonWasPushed("User1", 0, 5)
+	control.PickUpButtonWasPushed("User2", 9, 2)
+
+	if trips := control.Elevators[2].GetAssignedTrips(); len(trips) != 2 {
+		t.Errorf("expected the custom dispatcher to send both users to elevator 2, got %d trips", len(trips))
+	}
+}
+
+func TestUsersWaitWhenTheDispatcherChoosesNoElevator(t *testing.T) {
+	dispatcher := &lastElevatorDispatcher{closed: true}
+	control := newSystem(t, 3, 10, WithDispatcher(dispatcher))
+	control.PickUpButtonWasPushed("User1", 0, 5)
+
+	control.Advance(5)
+	if len(control.scheduled) != 1 || len(control.Elevators[2].GetAssignedTrips()) != 0 {
+		t.Fatalf("expected User1 to wait while the dispatcher chooses no elevator")
+	}
+
+	dispatcher.closed = false
+	control.Tick()
+	if len(control.scheduled) != 0 || len(control.Elevators[2].GetAssignedTrips()) != 1 {
+		t.Errorf("expected User1 to be dispatched in the next tick")
+	}
+}
+
+func TestDispatchersChooseNoElevatorWithoutElevators(t *testing.T) {
+	request := PickUpRequest{UserID: "User1", PickUpFloor: 0, DropOffFloor: 5}
+	for name, dispatcher := range map[string]Dispatcher{"proximity": ProximityDispatcher{}, "eta": ETADispatcher{}, "energy": EnergyDispatcher{}} {
+		if assignment := dispatcher.Dispatch([]Elevator{}, request); assignment.ElevatorID != NoElevator {
+			t.Errorf("%v: expected no elevator, got %+v", name, assignment)
+		}
+	}
+}
+
+func TestBuildingsWithoutElevatorsOrFloorsAreRejected(t *testing.T) {
+	for _, c := range []struct{ elevators, floors int }{{0, 10}, {-1, 10}, {2, -1}} {
+		if control, err := NewElevatorControlSystem(c.elevators, c.floors); control != nil || !errors.Is(err, ErrInvalidBuilding) {
+			t.Errorf("expected a building of %d elevators and %d floors to be rejected, got %v", c.elevators, c.floors, err)
+		}
+	}
+}
+
+func TestProximityDispatcherChoosesTheNearestElevator(t *testing.T) {
+	control := newSystem(t, 3, 10)
+	control.Update(1, 8, "STOPPED")
+	control.Update(2, 4, "UP")
+
+	request := PickUpRequest{UserID: "User1", PickUpFloor: 9, DropOffFloor: 0}
+	if chosen := (ProximityDispatcher{}).Dispatch(control.Elevators, request).ElevatorID; chosen != 1 {
+		t.Errorf("expected the stopped elevator in floor 8 to be chosen, got elevator %d", chosen)
+	}
+	request = PickUpRequest{UserID: "User2", PickUpFloor: 5, DropOffFloor: 7}
+	if chosen := (ProximityDispatcher{}).Dispatch(control.Elevators, request).ElevatorID; chosen != 2 {
+		t.Errorf("expected the elevator going up from floor 4 to be chosen, got elevator %d", chosen)
+	}
+}
+
+func TestETADispatcherTakesIntoAccountTheCommittedStops(t *testing.T) {
+	var controls []*elevatorControlSystem
+	for _, dispatcher := range []Dispatcher{ProximityDispatcher{}, ETADispatcher{}} {
+		control := newSystem(t, 2, 10, WithDispatcher(dispatcher))
+		// Elevator 0 is near the user, but it has to take two users up to floors 9 and 10 before
+		control.Update(0, 5, "UP")
+		control.Elevators[0].AssignTrip(TripDetails{UserID: "Rider1", Event: Boarded, FromFloor: 0, ToFloor: 9, Weight: averageUserWeight})
+		control.Elevators[0].AssignTrip(TripDetails{UserID: "Rider2", Event: Boarded, FromFloor: 0, ToFloor: 10, Weight: averageUserWeight})
+		controls = append(controls, control)
+	}
+
+	proximity, _ := controls[0].PickUpButtonWasPushed("User1", 4, 6)
+	eta, _ := controls[1].PickUpButtonWasPushed("User1", 4, 6)
+	if proximity.ElevatorID != 0 || eta.ElevatorID != 1 {
+		t.Fatalf("expected the nearest elevator 0 and the idle elevator 1 to be chosen, got %v and %v", proximity, eta)
+	}
+	if eta.ETA == UnknownETA || eta.ETA >= proximity.ETA {
+		t.Errorf("expected elevator 1 to arrive before elevator 0, got ETA %d and %d", eta.ETA, proximity.ETA)
+	}
+
+	// The estimations are the real times when the user gets into the elevator
+	for i, assignment := range []Assignment{proximity, eta} {
+		controls[i].Step()
+		for _, step := range controls[i].Elevators[assignment.ElevatorID].GetStepList() {
+			if step.UserID == "User1" && step.Event == Boarded && step.Time != assignment.ETA {
+				t.Errorf("expected User1 to get into elevator %d at t=%d, got t=%d", assignment.ElevatorID, assignment.ETA, step.Time)
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package lift
+
+import "math"
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               ENERGY CONSUMPTION                                ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Standard gravity, in m/s²
+const gravity = 9.81
+
+// Kilojoules in a kilowatt-hour
+const kilojoulesPerKWh = 3600
+
+/**
+ *	Model of the energy drawn by the motor and the rest of the equipment of an elevator. The counterweight balances
+	the car and a share of its rated load, so the motor only lifts the imbalance between the load and the counterweight:
+	an empty car going down, or a full one going up, consumes energy, but a full car going down, or an empty one going
+	up, drives the motor, and a share of that energy is given back to the grid.
+
+	Energies are in kilojoules
+*/
+type EnergyModel struct {
+	StartEnergy            float64 // To accelerate the car every time it starts moving
+	TravelEnergy           float64 // To travel a floor with the load balanced by the counterweight: friction and losses
+	FloorHeight            float64 // Meters between two floors
+	CounterweightBalance   float64 // Share of the rated load balanced by the counterweight, between 0 and 1
+	MotorEfficiency        float64 // Share of the energy drawn by the motor that lifts the imbalance, between 0 and 1
+	RegenerationEfficiency float64 // Share of the energy given back to the grid when the load drives the motor
+	StandbyEnergy          float64 // Drawn by the lights, ventilation and controller every time unit, even when idle
+}
+
+// Energy model of a typical traction elevator with regenerative drive
+func DefaultEnergyModel() EnergyModel {
+	return EnergyModel{
+		StartEnergy:            5,
+		TravelEnergy:           3,
+		FloorHeight:            3,
+		CounterweightBalance:   0.5,
+		MotorEfficiency:        0.8,
+		RegenerationEfficiency: 0.5,
+		StandbyEnergy:          0.2,
+	}
+}
+
+/**
+ *	Sets the energy model of every elevator of the system. By default the elevators use DefaultEnergyModel
+	@ model EnergyModel
+*/
+func WithEnergyModel(model EnergyModel) Option {
+	return func(control *elevatorControlSystem) {
+		for i := range control.Elevators {
+			control.Elevators[i].SetEnergyModel(model)
+		}
+	}
+}
+
+/**
+ *	Kilojoules drawn by an elevator in a tick. The floors are positive going up and negative going down
+	@ floors int
+	@ started bool: the elevator was not moving in the same direction before this tick
+	@ load int: kilograms of the users in the elevator
+	@ ratedLoad int: maximum load of the elevator, in kilograms
+*/
+func (model EnergyModel) energyOfTheTick(floors int, started bool, load int, ratedLoad int) float64 {
+	energy := model.StandbyEnergy
+	if floors == 0 {
+		return energy
+	}
+	if started {
+		energy += model.StartEnergy
+	}
+	energy += model.TravelEnergy * math.Abs(float64(floors))
+
+	// Positive when the motor lifts the imbalance, negative when the imbalance drives the motor
+	imbalance := float64(load) - model.CounterweightBalance*float64(ratedLoad)
+	lift := imbalance * gravity * model.FloorHeight * float64(floors) / 1000
+	if lift > 0 {
+		return energy + lift/model.MotorEfficiency
+	}
+	return energy + lift*model.RegenerationEfficiency
+}
+
+/**
+ *	Tells how many kilojoules more an elevator would draw to complete its trips if the request was assigned to it
+	now, or +Inf if it wouldn't complete the trip of the user. The elevator is replayed with and without the new
+	trip, for the same time, so the standby energy is only counted once
+	@ elev Elevator
+	@ request PickUpRequest
+*/
+func EstimateMarginalEnergy(elev Elevator, request PickUpRequest) float64 {
+	return elev.EstimateMarginalEnergy(request.trip())
+}
+
+func (elev *elevator) SetEnergyModel(model EnergyModel) {
+	elev.energyModel = model
+}
+
+func (elev *elevator) EstimateMarginalEnergy(trip TripDetails) float64 {
+	without, with := elev.ghost(), elev.ghost(trip)
+	for now := 0; !with.isDone() || !without.isDone(); now++ {
+		without.Tick(now)
+		with.Tick(now)
+	}
+	if len(with.failures) > len(without.failures) {
+		return math.Inf(1)
+	}
+	return with.odometer.Energy - without.odometer.Energy
+}
+
+// Tells if the elevator has nothing more to do, or gave up its trips
+func (elev *elevator) isDone() bool {
+	return len(elev.failures) > 0 || len(elev.assignedTrips) == 0 && (elev.state == Idle || elev.state == OutOfService)
+}
+
+/**
+ *	Dispatcher choosing the elevator that would draw the least energy to take the user, replacing the guess of
+	chooseTheMostOptimalElevator about the users going to the same floor by the EnergyModel of every elevator.
+	The marginal energy of every elevator in service with room for the user is estimated with EstimateMarginalEnergy,
+	and the ties are broken by the earliest arrival to the pick-up floor
+*/
+type EnergyDispatcher struct{}
+
+func (EnergyDispatcher) Dispatch(elevators []Elevator, request PickUpRequest) Assignment {
+	best := Assignment{ElevatorID: NoElevator, ETA: UnknownETA}
+	leastEnergy := math.Inf(1)
+	for i := range elevators {
+		if !canTakeAnotherUser(elevators[i]) {
+			continue
+		}
+		energy := EstimateMarginalEnergy(elevators[i], request)
+		if math.IsInf(energy, 1) {
+			continue
+		}
+		eta := EstimateTimeToPickUp(elevators[i], request)
+		if energy < leastEnergy || energy == leastEnergy && eta < best.ETA {
+			best = Assignment{ElevatorID: i, ETA: eta}
+			leastEnergy = energy
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,62 @@
+package lift
+
+import "testing"
+
+func TestEnergyOfTheTick(t *testing.T) {
+	model := DefaultEnergyModel()
+	balanced := int(model.CounterweightBalance * defaultRatedLoad)
+
+	tests := []struct {
+		name     string
+		floors   int
+		started  bool
+		load     int
+		expected func(energy float64) bool
+	}{
+		{"an idle elevator only draws the standby energy", 0, false, 0,
+			func(energy float64) bool { return energy == model.StandbyEnergy }},
+		{"a balanced elevator draws the travel energy", 1, false, balanced,
+			func(energy float64) bool { return energy == model.StandbyEnergy+model.TravelEnergy }},
+		{"starting to move draws the start energy", 1, true, balanced,
+			func(energy float64) bool { return energy == model.StandbyEnergy+model.StartEnergy+model.TravelEnergy }},
+		{"a full elevator going up lifts the imbalance", 1, false, defaultRatedLoad,
+			func(energy float64) bool { return energy > model.StandbyEnergy+model.TravelEnergy }},
+		{"a full elevator going down gives energy back", -1, false, defaultRatedLoad,
+			func(energy float64) bool { return energy < model.StandbyEnergy+model.TravelEnergy }},
+		{"an empty elevator going up gives energy back", 1, false, 0,
+			func(energy float64) bool { return energy < model.StandbyEnergy+model.TravelEnergy }},
+		{"an empty elevator going down lifts the counterweight", -1, false, 0,
+			func(energy float64) bool { return energy > model.StandbyEnergy+model.TravelEnergy }},
+	}
+	for _, tt := range tests {
+		if energy := model.energyOfTheTick(tt.floors, tt.started, tt.load, defaultRatedLoad); !tt.expected(energy) {
+			t.Errorf("%v: got %v kJ", tt.name, energy)
+		}
+	}
+}
+
+func TestEnergyDispatcherChoosesTheElevatorDrawingTheLeastEnergy(t *testing.T) {
+	// Both elevators are one floor away, but the empty one going up to the user is driven by the counterweight.
+	// With LOOK it doesn't ride to the top floor before taking the user down
+	newControl := func(dispatcher Dispatcher) ElevatorControlSystem {
+		control := newSystem(t, 2, 10, WithDispatcher(dispatcher), WithMovementPolicy(LOOK))
+		control.Update(0, 6, "STOPPED")
+		control.Update(1, 4, "STOPPED")
+		return control
+	}
+
+	byTime, _ := newControl(ETADispatcher{}).PickUpButtonWasPushed("User1", 5, 0)
+	byEnergy, _ := newControl(EnergyDispatcher{}).PickUpButtonWasPushed("User1", 5, 0)
+	if byTime.ElevatorID != 0 || byEnergy.ElevatorID != 1 || byEnergy.ETA != byTime.ETA {
+		t.Errorf("expected the ETA dispatcher to choose elevator 0 and the energy one elevator 1, arriving at the same time, got %+v and %+v",
+			byTime, byEnergy)
+	}
+}
+
+func TestMarginalEnergyOfAnUnreachableTripIsInfinite(t *testing.T) {
+	elev := NewElevator(0, 10)
+	trip := PickUpRequest{UserID: "Ghost", PickUpFloor: 12, DropOffFloor: 2}
+	if energy := EstimateMarginalEnergy(elev, trip); energy < 1e308 {
+		t.Errorf("expected the trip to an unreachable floor to draw infinite energy, got %v", energy)
+	}
+}
@@ -0,0 +1,127 @@
+package lift
+
+import "fmt"
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               STEP EVENTS AND THEIR TEXT FORMATTER              ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+/**
+ *	What happened to a trip in a step, or to an elevator. The last event of a trip also tells in which state the
+	trip is. The events of the elevators are not steps of the trips, they are only published to the subscribers
+*/
+type EventKind int
+
+const (
+	Assigned           EventKind = iota // The user pushed the pick-up button, and an elevator was assigned to take him
+	Boarded                             // The user got into the elevator
+	Alighted                            // The user stepped out of the elevator in the drop-off floor
+	HallCallRegistered                  // A user pushed the up or down button in a floor, and an elevator will answer it
+	HallCallAnswered                    // The elevator opened its doors in the floor of the hall call
+	CarCallRegistered                   // A user in the elevator pushed the button of a floor
+	CarCallServed                       // The elevator opened its doors in the floor of the car call
+	CarArrived                          // The elevator arrived to a floor, whether it stops there or not
+	DoorsOpened                         // The elevator opened its doors
+	DoorsClosed                         // The elevator closed its doors
+)
+
+var eventKindNames = map[EventKind]string{
+	Assigned:           "ASSIGNED",
+	Boarded:            "BOARDED",
+	Alighted:           "ALIGHTED",
+	HallCallRegistered: "HALL_CALL_REGISTERED",
+	HallCallAnswered:   "HALL_CALL_ANSWERED",
+	CarCallRegistered:  "CAR_CALL_REGISTERED",
+	CarCallServed:      "CAR_CALL_SERVED",
+	CarArrived:         "CAR_ARRIVED",
+	DoorsOpened:        "DOORS_OPENED",
+	DoorsClosed:        "DOORS_CLOSED",
+}
+
+func (kind EventKind) String() string {
+	if name, ok := eventKindNames[kind]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+/**
+ *	Tells the kind of event with that name, like CAR_ARRIVED or BOARDED
+	@ name string
+*/
+func ParseEventKind(name string) (EventKind, error) {
+	for kind, kindName := range eventKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownEventKind, name)
+}
+
+// Tells if the event is the first one of a trip, when it is assigned to an elevator
+func (kind EventKind) registersATrip() bool {
+	return kind == Assigned || kind == HallCallRegistered || kind == CarCallRegistered
+}
+
+// Tells if the elevator has still to go to the pick-up floor of the trip
+func (kind EventKind) isWaiting() bool {
+	return kind == Assigned || kind == HallCallRegistered
+}
+
+// Tells if the elevator has still to go to the drop-off floor of the trip
+func (kind EventKind) isOnBoard() bool {
+	return kind == Boarded || kind == CarCallRegistered
+}
+
+/***** TEXT FORMATTER *************/
+
+// Tells in plain English what happened in a step
+func describeStep(step TripDetails) string {
+	switch step.Event {
+	case Assigned:
+		return fmt.Sprintf("%v pressed the pick-up button in floor %d and wants to go to floor %d. This elevator will take him there",
+			step.UserID, step.FromFloor, step.ToFloor)
+	case Boarded:
+		return fmt.Sprintf("%v is getting into the elevator", step.UserID)
+	case Alighted:
+		return fmt.Sprintf("%v is exiting from the elevator in floor %d", step.UserID, step.ElevatorFloor)
+	case HallCallRegistered:
+		return fmt.Sprintf("%v pushed in floor %d. This elevator will answer it", step.UserID, step.FromFloor)
+	case HallCallAnswered:
+		return fmt.Sprintf("%v is answered in floor %d", step.UserID, step.FromFloor)
+	case CarCallRegistered:
+		return fmt.Sprintf("%v pushed", step.UserID)
+	case CarCallServed:
+		return fmt.Sprintf("%v is served", step.UserID)
+	case CarArrived:
+		return fmt.Sprintf("Arrived to floor %d", step.ElevatorFloor)
+	case DoorsOpened:
+		return "Doors opened"
+	case DoorsClosed:
+		return "Doors closed"
+	}
+	return fmt.Sprintf("%v %v", step.UserID, step.Event)
+}
+
+// Tells in plain English a task assigned to an elevator
+func describeTask(trip TripDetails) string {
+	switch trip.Event {
+	case Assigned:
+		return fmt.Sprintf("%v is in floor %d This elevator will take him there. Wants to go to floor %d",
+			trip.UserID, trip.FromFloor, trip.ToFloor)
+	case Boarded:
+		return fmt.Sprintf("%v is in floor %d getting into the elevator. Wants to go to floor %d",
+			trip.UserID, trip.FromFloor, trip.ToFloor)
+	case HallCallRegistered:
+		return fmt.Sprintf("%v pushed in floor %d", trip.UserID, trip.FromFloor)
+	case CarCallRegistered:
+		return fmt.Sprintf("%v pushed", trip.UserID)
+	}
+	return fmt.Sprintf("%v %v", trip.UserID, trip.Event)
+}
+
+/***** END OF TEXT FORMATTER *************/
@@ -0,0 +1,57 @@
+package lift
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEveryTripGoesThroughItsEvents(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.Update(1, 9, "STOPPED")
+	control.PickUpButtonWasPushed("User1", 2, 4)
+	control.HallCall(6, DOWN)
+	control.CarCall(1, 3)
+	control.Step()
+
+	tests := []struct {
+		elevatorID int
+		want       []EventKind
+	}{
+		{0, []EventKind{Assigned, Boarded, Alighted}},
+		{1, []EventKind{HallCallRegistered, CarCallRegistered, HallCallAnswered, CarCallServed}},
+	}
+	status := control.Snapshot()
+	for _, tt := range tests {
+		steps := status.Elevators[tt.elevatorID].Steps
+		if len(steps) != len(tt.want) {
+			t.Fatalf("elevator %d: expected the events %v, got %+v", tt.elevatorID, tt.want, steps)
+		}
+		for i := range tt.want {
+			if steps[i].Event != tt.want[i] {
+				t.Errorf("elevator %d: expected the event %d to be %v, got %v", tt.elevatorID, i, tt.want[i], steps[i].Event)
+			}
+		}
+	}
+}
+
+func TestEventKindNames(t *testing.T) {
+	for kind := Assigned; kind <= DoorsClosed; kind++ {
+		if kind.String() == "UNKNOWN" || describeStep(TripDetails{UserID: "User1", Event: kind}) == "" {
+			t.Errorf("expected the event %d to have a name and a description", kind)
+		}
+	}
+	if EventKind(99).String() != "UNKNOWN" {
+		t.Errorf("expected unknown events to be UNKNOWN")
+	}
+}
+
+func TestEventKindsAreParsedByTheirNames(t *testing.T) {
+	for kind := range eventKindNames {
+		if parsed, err := ParseEventKind(kind.String()); parsed != kind || err != nil {
+			t.Errorf("%v: expected to be parsed, got %v %v", kind, parsed, err)
+		}
+	}
+	if _, err := ParseEventKind("LANDED"); !errors.Is(err, ErrUnknownEventKind) {
+		t.Errorf("expected an unknown kind of event to be rejected, got %v", err)
+	}
+}
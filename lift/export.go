@@ -0,0 +1,175 @@
+package lift
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               JSON LINES AND CSV EXPORT                         ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// A step performed by an elevator, with stable field names for the analysis tools
+type StepRecord struct {
+	Time      int       `json:"time"`
+	Elevator  int       `json:"elevator"`
+	Floor     int       `json:"floor"`
+	Direction Direction `json:"direction"`
+	User      string    `json:"user"`
+	Call      string    `json:"call"`
+	Action    string    `json:"action"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	CalledAt  int       `json:"called_at"` // When the user called the elevator, so the trip can be replayed
+}
+
+// The state of an elevator in a snapshot, with stable field names for the analysis tools
+type CarRecord struct {
+	Time      int          `json:"time"`
+	Elevator  int          `json:"elevator"`
+	Floor     int          `json:"floor"`
+	Direction Direction    `json:"direction"`
+	State     string       `json:"state"`
+	Persons   int          `json:"persons"`
+	Load      int          `json:"load"`
+	Trips     []TripRecord `json:"trips"`
+	Failures  []string     `json:"failures"`
+}
+
+// A trip assigned to an elevator in a snapshot, with stable field names for the analysis tools
+type TripRecord struct {
+	User   string `json:"user"`
+	Call   string `json:"call"`
+	Action string `json:"action"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+}
+
+var stepCSVHeader = []string{"time", "elevator", "floor", "direction", "user", "call", "action", "from", "to", "called_at"}
+var statusCSVHeader = []string{"time", "elevator", "floor", "direction", "state", "persons", "load", "user", "call", "action", "from", "to"}
+
+// Renders the steps performed by every elevator as JSON Lines, one StepRecord per line
+type StepListJSONLinesRenderer struct{}
+
+func (StepListJSONLinesRenderer) Render(w io.Writer, status SystemStatus) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range StepRecords(status) {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Renders the steps performed by every elevator as CSV, with a header and one StepRecord per row
+type StepListCSVRenderer struct{}
+
+func (StepListCSVRenderer) Render(w io.Writer, status SystemStatus) error {
+	rows := [][]string{stepCSVHeader}
+	for _, record := range StepRecords(status) {
+		rows = append(rows, []string{
+			strconv.Itoa(record.Time), strconv.Itoa(record.Elevator), strconv.Itoa(record.Floor), string(record.Direction),
+			record.User, record.Call, record.Action, strconv.Itoa(record.From), strconv.Itoa(record.To),
+			strconv.Itoa(record.CalledAt),
+		})
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// Renders the state of every elevator as JSON Lines, one CarRecord per line
+type StatusJSONLinesRenderer struct{}
+
+func (StatusJSONLinesRenderer) Render(w io.Writer, status SystemStatus) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range CarRecords(status) {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ *	Renders the state of every elevator as CSV, with a header and one row per trip assigned to an elevator. The
+	elevators without trips have a row with empty trip fields, so all of them are in the table
+*/
+type StatusCSVRenderer struct{}
+
+func (StatusCSVRenderer) Render(w io.Writer, status SystemStatus) error {
+	rows := [][]string{statusCSVHeader}
+	for _, record := range CarRecords(status) {
+		car := []string{
+			strconv.Itoa(record.Time), strconv.Itoa(record.Elevator), strconv.Itoa(record.Floor), string(record.Direction),
+			record.State, strconv.Itoa(record.Persons), strconv.Itoa(record.Load),
+		}
+		if len(record.Trips) == 0 {
+			rows = append(rows, append(car, "", "", "", "", ""))
+		}
+		for _, trip := range record.Trips {
+			rows = append(rows, append(append([]string{}, car...),
+				trip.User, trip.Call, trip.Action, strconv.Itoa(trip.From), strconv.Itoa(trip.To)))
+		}
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
+
+// The steps of every elevator of the snapshot as records, in the order of the elevators and then of the time
+func StepRecords(status SystemStatus) []StepRecord {
+	records := []StepRecord{}
+	for _, car := range status.Elevators {
+		for _, step := range car.Steps {
+			records = append(records, StepRecord{
+				Time:      step.Time,
+				Elevator:  car.ElevatorID,
+				Floor:     step.ElevatorFloor,
+				Direction: step.ElevatorDirection,
+				User:      step.UserID,
+				Call:      step.Call.String(),
+				Action:    step.Event.String(),
+				From:      step.FromFloor,
+				To:        step.ToFloor,
+				CalledAt:  step.CalledAt,
+			})
+		}
+	}
+	return records
+}
+
+// The state of every elevator of the snapshot as records, in the order of the elevators
+func CarRecords(status SystemStatus) []CarRecord {
+	records := []CarRecord{}
+	for _, car := range status.Elevators {
+		record := CarRecord{
+			Time:      status.Time,
+			Elevator:  car.ElevatorID,
+			Floor:     car.Floor,
+			Direction: car.Direction,
+			State:     car.State.String(),
+			Persons:   car.Persons,
+			Load:      car.Load,
+			Trips:     []TripRecord{},
+			Failures:  []string{},
+		}
+		for _, trip := range car.Trips {
+			record.Trips = append(record.Trips, TripRecord{
+				User:   trip.UserID,
+				Call:   trip.Call.String(),
+				Action: trip.Event.String(),
+				From:   trip.FromFloor,
+				To:     trip.ToFloor,
+			})
+		}
+		for _, failure := range car.Failures {
+			record.Failures = append(record.Failures, failure.Error())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
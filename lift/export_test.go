@@ -0,0 +1,101 @@
+package lift
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func exportScenario(t *testing.T) ElevatorControlSystem {
+	control := newSystem(t, 3, 10)
+	control.Update(1, 8, "STOPPED")
+	control.PickUpButtonWasPushed("User1", 0, 3)
+	control.HallCall(9, DOWN)
+	control.Advance(2)
+	return control
+}
+
+func TestStepListIsExportedAsJSONLinesAndCSV(t *testing.T) {
+	control := exportScenario(t)
+	control.Step()
+	status := control.Snapshot()
+
+	output := strings.Builder{}
+	if err := (StepListJSONLinesRenderer{}).Render(&output, status); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	records := []StepRecord{}
+	scanner := bufio.NewScanner(strings.NewReader(output.String()))
+	for scanner.Scan() {
+		record := StepRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("expected a JSON object per line, got %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	want := []StepRecord{
+		{Time: 0, Elevator: 0, Floor: 0, Direction: STOPPED, User: "User1", Call: "PASSENGER", Action: "ASSIGNED", From: 0, To: 3},
+		{Time: 1, Elevator: 0, Floor: 0, Direction: STOPPED, User: "User1", Call: "PASSENGER", Action: "BOARDED", From: 0, To: 3},
+		{Time: 7, Elevator: 0, Floor: 3, Direction: UP, User: "User1", Call: "PASSENGER", Action: "ALIGHTED", From: 0, To: 3},
+		{Time: 0, Elevator: 1, Floor: 8, Direction: STOPPED, User: "Hall call DOWN", Call: "HALL", Action: "HALL_CALL_REGISTERED", From: 9, To: UnknownFloor},
+		{Time: 2, Elevator: 1, Floor: 9, Direction: UP, User: "Hall call DOWN", Call: "HALL", Action: "HALL_CALL_ANSWERED", From: 9, To: UnknownFloor},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), records)
+	}
+	for i := range want {
+		if records[i] != want[i] {
+			t.Errorf("step %d: expected %+v, got %+v", i, want[i], records[i])
+		}
+	}
+
+	output.Reset()
+	if err := (StepListCSVRenderer{}).Render(&output, status); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(output.String())).ReadAll()
+	if err != nil || len(rows) != len(want)+1 {
+		t.Fatalf("expected a header and %d rows, got %v, %v", len(want), rows, err)
+	}
+	if strings.Join(rows[0], ",") != "time,elevator,floor,direction,user,call,action,from,to,called_at" ||
+		strings.Join(rows[3], ",") != "7,0,3,UP,User1,PASSENGER,ALIGHTED,0,3,0" {
+		t.Errorf("unexpected CSV %v", rows)
+	}
+}
+
+func TestStatusIsExportedAsJSONLinesAndCSV(t *testing.T) {
+	status := exportScenario(t).Snapshot()
+
+	output := strings.Builder{}
+	if err := (StatusJSONLinesRenderer{}).Render(&output, status); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a line per elevator, got %q", output.String())
+	}
+	car := CarRecord{}
+	if err := json.Unmarshal([]byte(lines[0]), &car); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if car.Time != 2 || car.Elevator != 0 || car.State != "DOORS OPEN" || car.Persons != 1 || len(car.Trips) != 1 ||
+		car.Trips[0] != (TripRecord{User: "User1", Call: "PASSENGER", Action: "BOARDED", From: 0, To: 3}) {
+		t.Errorf("unexpected status of elevator 0 %+v", car)
+	}
+
+	output.Reset()
+	if err := (StatusCSVRenderer{}).Render(&output, status); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(output.String())).ReadAll()
+	if err != nil || len(rows) != 4 {
+		t.Fatalf("expected a header and a row per elevator, got %v, %v", rows, err)
+	}
+	if strings.Join(rows[1], ",") != "2,0,0,STOPPED,DOORS OPEN,1,75,User1,PASSENGER,BOARDED,0,3" ||
+		strings.Join(rows[2], ",") != "2,1,9,UP,OPENING DOORS,0,0,Hall call DOWN,HALL,HALL_CALL_REGISTERED,9,-1" ||
+		strings.Join(rows[3], ",") != "2,2,0,STOPPED,IDLE,0,0,,,,," {
+		t.Errorf("unexpected CSV %v", rows)
+	}
+}
@@ -0,0 +1,162 @@
+package lift
+
+import "fmt"
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               PASSENGER JOURNEYS                                ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Simulated time of a journey milestone that has not happened yet
+const NotYet = -1
+
+/**
+ *	Lifecycle of the trip of a user, from the moment he pushes the pick-up button until he steps out of the elevator
+	in the drop-off floor. Every milestone is stamped with the simulated time when it happened, or NotYet
+*/
+type Journey struct {
+	TripID       int // Trip of the user, told apart from the other ones of the same user
+	UserID       string
+	PickUpFloor  int
+	DropOffFloor int
+	ElevatorID   int   // Elevator assigned to the user, NoElevator while he waits for one with room
+	CalledAt     int   // When the user pushed the pick-up button
+	AssignedAt   int   // When an elevator was assigned to him
+	BoardedAt    int   // When he got into the elevator
+	AlightedAt   int   // When he stepped out of the elevator in the drop-off floor
+	FailedAt     int   // When the elevator gave up his trip because it was making no progress
+	Failure      error // The StuckTripError of the failed trips
+}
+
+// Time units the user waited in the pick-up floor, or NotYet while he is waiting
+func (journey Journey) WaitTime() int {
+	if journey.BoardedAt == NotYet {
+		return NotYet
+	}
+	return journey.BoardedAt - journey.CalledAt
+}
+
+// Time units the user travelled in the elevator, or NotYet until he steps out
+func (journey Journey) RideTime() int {
+	if journey.BoardedAt == NotYet || journey.AlightedAt == NotYet {
+		return NotYet
+	}
+	return journey.AlightedAt - journey.BoardedAt
+}
+
+// Time units since the user pushed the pick-up button until he stepped out, or NotYet until he steps out
+func (journey Journey) JourneyTime() int {
+	if journey.AlightedAt == NotYet {
+		return NotYet
+	}
+	return journey.AlightedAt - journey.CalledAt
+}
+
+// Tells if the user already arrived to the drop-off floor
+func (journey Journey) Completed() bool {
+	return journey.AlightedAt != NotYet
+}
+
+// Tells if the journey is still going on
+func (journey Journey) inProgress() bool {
+	return journey.AlightedAt == NotYet && journey.FailedAt == NotYet
+}
+
+
+/**
+ *	Tells the journeys of a user, in the order he pushed the pick-up button. We use it to answer complaints like
+	"User7 waited 3 minutes"
+	@ userID string
+*/
+func (control *elevatorControlSystem) Passenger(userID string) ([]Journey, error) {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	journeys := []Journey{}
+	for i := range control.journeys {
+		if control.journeys[i].UserID == userID {
+			journeys = append(journeys, *control.journeys[i])
+		}
+	}
+	if len(journeys) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownPassenger, userID)
+	}
+	return journeys, nil
+}
+
+// Starts the journey of the user that made a pick-up request. Hall calls are not made by a known user
+func (control *elevatorControlSystem) startJourney(request PickUpRequest) {
+	if request.DropOffFloor == UnknownFloor {
+		return
+	}
+	control.journeys = append(control.journeys, &Journey{
+		TripID:       request.TripID,
+		UserID:       request.UserID,
+		PickUpFloor:  request.PickUpFloor,
+		DropOffFloor: request.DropOffFloor,
+		ElevatorID:   NoElevator,
+		CalledAt:     request.At,
+		AssignedAt:   NotYet,
+		BoardedAt:    NotYet,
+		AlightedAt:   NotYet,
+		FailedAt:     NotYet,
+	})
+}
+
+// Finds the journey going on for a trip, nil if there isn't one, i.e. for the hall and car calls
+func (control *elevatorControlSystem) findJourney(tripID int) *Journey {
+	for _, journey := range control.journeys {
+		if journey.TripID == tripID && journey.inProgress() {
+			return journey
+		}
+	}
+	return nil
+}
+
+// Takes note of the elevator assigned to the user of the request
+func (control *elevatorControlSystem) assignJourney(request PickUpRequest, elevatorID int) {
+	if journey := control.findJourney(request.TripID); journey != nil {
+		journey.ElevatorID = elevatorID
+		journey.AssignedAt = control.clock
+	}
+}
+
+// Takes note of the users who got into or stepped out of the elevators, and of the trips that failed, since the last tick
+func (control *elevatorControlSystem) followTheJourneys() {
+	for len(control.stepsSeen) < len(control.Elevators) {
+		control.stepsSeen = append(control.stepsSeen, 0)
+		control.failuresSeen = append(control.failuresSeen, 0)
+	}
+
+	for i := range control.Elevators {
+		elev := control.Elevators[i]
+		for ; control.stepsSeen[i] < elev.GetNumberOfSteps(); control.stepsSeen[i]++ {
+			step := elev.GetStep(control.stepsSeen[i])
+			journey := control.findJourney(step.TripID)
+			if journey == nil || step.Call != PassengerCall {
+				continue
+			}
+			switch step.Event {
+			case Boarded:
+				journey.BoardedAt = step.Time
+			case Alighted:
+				journey.AlightedAt = step.Time
+			}
+		}
+
+		failures := control.Elevators[i].GetFailures()
+		for _, failure := range failures[control.failuresSeen[i]:] {
+			stuck, ok := failure.(*StuckTripError)
+			if !ok {
+				continue
+			}
+			if journey := control.findJourney(stuck.TripID); journey != nil {
+				journey.FailedAt = stuck.Time
+				journey.Failure = stuck
+			}
+		}
+		control.failuresSeen[i] = len(failures)
+	}
+}
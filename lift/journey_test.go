@@ -0,0 +1,73 @@
+package lift
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPassengerJourneyTellsHowLongTheUserWaitedAndRode(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.Update(0, 5, "STOPPED")
+	control.Advance(2)
+	control.PickUpButtonWasPushed("User7", 0, 3)
+	control.Step()
+
+	journeys, err := control.Passenger("User7")
+	if err != nil || len(journeys) != 1 {
+		t.Fatalf("expected one journey of User7, got %+v, %v", journeys, err)
+	}
+	journey := journeys[0]
+	if journey.ElevatorID != 0 || journey.CalledAt != 2 || journey.AssignedAt != 2 {
+		t.Errorf("expected User7 to be assigned elevator 0 at t=2, got %+v", journey)
+	}
+	// The elevator goes down 5 floors and opens its doors, then goes up 3 floors and opens them again
+	if journey.WaitTime() != 6 || journey.RideTime() != 6 || journey.JourneyTime() != 12 || !journey.Completed() {
+		t.Errorf("expected User7 to wait 6 and ride 6 time units, got %+v", journey)
+	}
+}
+
+func TestPassengerJourneyOfAStuckTripIsFailed(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 3, 6)
+	control.Update(0, 0, string(OUT_OF_SERVICE))
+	control.Step()
+
+	journeys, _ := control.Passenger("User1")
+	journey := journeys[0]
+	if journey.FailedAt == NotYet || !errors.Is(journey.Failure, ErrNoProgress) {
+		t.Fatalf("expected the journey of User1 to fail, got %+v", journey)
+	}
+	if journey.WaitTime() != NotYet || journey.Completed() {
+		t.Errorf("expected User1 to never board the elevator, got %+v", journey)
+	}
+}
+
+func TestTheSameTripTwiceMakesTwoJourneys(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 0, 5)
+	control.Step()
+	control.PickUpButtonWasPushed("User1", 0, 5)
+	control.SchedulePickUp(30, "User1", 0, 5)
+	control.SchedulePickUp(30, "User1", 0, 5)
+	control.Step()
+
+	journeys, _ := control.Passenger("User1")
+	if len(journeys) != 4 {
+		t.Fatalf("expected 4 journeys of User1, got %+v", journeys)
+	}
+	for i, journey := range journeys {
+		if !journey.Completed() || journey.WaitTime() == NotYet {
+			t.Errorf("expected the journey %d of User1 to be completed, got %+v", i, journey)
+		}
+	}
+	if report := control.Report(); report.Journeys != 4 || report.Completed != 4 {
+		t.Errorf("expected the 4 journeys to be completed, got %d journeys and %d completed", report.Journeys, report.Completed)
+	}
+}
+
+func TestUnknownPassengerIsRejected(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	if _, err := control.Passenger("Nobody"); !errors.Is(err, ErrUnknownPassenger) {
+		t.Errorf("expected %v, got %v", ErrUnknownPassenger, err)
+	}
+}
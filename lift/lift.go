@@ -0,0 +1,1355 @@
+/*
+Package lift is an elevator control system. It dispatches the pick-up requests of the users to a fleet of elevators
+in a building, and simulates their trips tick by tick.
+
+	control, err := lift.NewElevatorControlSystem(16, 10)
+	if err != nil {
+		return err
+	}
+	control.PickUpButtonWasPushed("User1", 0, 10)
+	control.Step()
+
+The controller is the ElevatorControlSystem interface, every elevator of the fleet is an Elevator, and the trips
+assigned to them, and the steps they perform to complete them, are TripDetails values.
+*/
+package lift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***  ELEVATOR CONTROL SYSTEM INTERFACE DEFINITION AND FUNCIONALITY  ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// The Elevator Control System Interface
+type ElevatorControlSystem interface {
+	Status()
+	Update(elevatorID int, floor int, direction string) error
+	PickUpButtonWasPushed(userID string, pickUpFloor int, dropOffFloor int) (Assignment, error)
+	SchedulePickUp(at int, userID string, pickUpFloor int, dropOffFloor int) error
+	Step() error
+	Tick() error
+	Advance(timeUnits int) error
+	RunUntilIdle(ctx context.Context) error
+	Now() int
+	Failures() []error
+	HallCall(floor int, direction Direction) (Assignment, error)
+	CarCall(elevatorID int, floor int) error
+	Passenger(userID string) ([]Journey, error)
+	Report() Report
+	Snapshot() SystemStatus
+	Subscribe(callback func(Event), kinds ...EventKind) *Subscription
+	SubscribeChannel(size int, backpressure Backpressure, kinds ...EventKind) *Subscription
+	GetElevator(elevatorID int) (CarStatus, error)
+	Run(ctx context.Context, calls <-chan Call) error
+}
+
+// Errors returned by the Elevator Control System Interface when it is asked to do something impossible.
+// They are wrapped with the details of the request, so they must be checked with errors.Is
+var (
+	ErrFloorOutOfRange    = errors.New("floor out of range")
+	ErrSameFloor          = errors.New("pick-up and drop-off floors are the same")
+	ErrUnknownElevator    = errors.New("unknown elevator")
+	ErrInvalidDirection   = errors.New("invalid direction")
+	ErrUnknownPassenger   = errors.New("unknown passenger")
+	ErrUnknownCall        = errors.New("unknown kind of call")
+	ErrUnknownEventKind   = errors.New("unknown kind of event")
+	ErrAlreadyRunning     = errors.New("already running in real time")
+	ErrInvalidStateChange = errors.New("invalid state change")
+	ErrInvalidBuilding    = errors.New("invalid building")
+)
+
+// Stores the information generated the Elevator Control System
+type elevatorControlSystem struct {
+	Elevators    []Elevator      // List of the elevators in our system and their current status
+	NUMELEVATORS int             // Number of elevators in our system
+	TOPFLOOR     int             // Top floor building in our system
+	clock        int             // Simulated time units elapsed since the system was started
+	scheduled    []PickUpRequest // Pick-up requests waiting to be assigned to an elevator, sorted by time
+	dispatcher   Dispatcher      // Chooses the elevator that will take every user
+	journeys     []*Journey      // Lifecycle of the trip of every user, in the order they pushed the pick-up button
+	stepsSeen    []int           // Steps of every elevator already noted in the journeys
+	failuresSeen []int           // Failures of every elevator already noted in the journeys
+	bus          eventBus        // Subscribers of the events of the elevators
+	mutex        sync.Mutex      // Serialises the callers of the system, which can be many goroutines at once
+	timeUnit     time.Duration   // Wall clock duration of a time unit when the system runs in real time
+	running      bool            // Tells if the system is running in real time
+	lastTripID   int             // ID of the last trip requested, so every trip has its own one
+}
+
+// Optional settings of the Elevator Control System, applied once all the elevators have been created
+type Option func(control *elevatorControlSystem)
+
+/**
+ * Initializes the Elevator Control System Intefrace. It returns ErrInvalidBuilding if there's no elevator, or the
+	top floor is under the ground floor, since no user could ever be taken anywhere
+	@ numberOfElevators int
+	@ numberOfFloors int
+	@ options ...Option
+*/
+func NewElevatorControlSystem(numberOfElevators int, numberOfFloors int, options ...Option) (ElevatorControlSystem, error) {
+	if numberOfElevators < 1 {
+		return nil, fmt.Errorf("%w: the building needs at least 1 elevator, got %d", ErrInvalidBuilding, numberOfElevators)
+	}
+	if numberOfFloors < 0 {
+		return nil, fmt.Errorf("%w: the top floor can't be under the ground floor, got %d", ErrInvalidBuilding, numberOfFloors)
+	}
+
+	control := &elevatorControlSystem{
+		Elevators:    []Elevator{},
+		NUMELEVATORS: numberOfElevators,
+		TOPFLOOR:     numberOfFloors,
+		dispatcher:   ProximityDispatcher{},
+		timeUnit:     defaultTimeUnit,
+	}
+
+	for i := 0; i < numberOfElevators; i++ {
+		control.Elevators = append(control.Elevators, NewElevator(i, control.TOPFLOOR))
+	}
+
+	for _, option := range options {
+		option(control)
+	}
+
+	return control, nil
+}
+
+/**
+ *	Sets how many persons and how many kilograms can carry every elevator of the system. By default every
+	elevator carries 8 persons and 630 kg
+	@ persons int
+	@ ratedLoad int: kilograms
+*/
+func WithCapacity(persons int, ratedLoad int) Option {
+	return func(control *elevatorControlSystem) {
+		for i := range control.Elevators {
+			control.Elevators[i].SetCapacity(persons, ratedLoad)
+		}
+	}
+}
+
+/**
+ *	Sets how many persons and how many kilograms can carry one of the elevators of the system. It is ignored
+	if the elevator doesn't exist
+	@ elevatorID int
+	@ persons int
+	@ ratedLoad int: kilograms
+*/
+func WithCarCapacity(elevatorID int, persons int, ratedLoad int) Option {
+	return func(control *elevatorControlSystem) {
+		if elev, err := control.getElevator(elevatorID); err == nil {
+			elev.SetCapacity(persons, ratedLoad)
+		}
+	}
+}
+
+/**
+ * 	It tells the status of every elevator of the system: which floor is in, if it is going up, going down
+	or if it is stopped. It also will tell the list of tasks it has been assigned so far from the list of
+	pick-up requests done by the users so far. It prints the Snapshot of the system with the StatusTextRenderer
+*/
+func (control *elevatorControlSystem) Status() {
+	StatusTextRenderer{}.Render(os.Stdout, control.Snapshot())
+}
+
+/****
+* It allows to the elevator controler system to intentionally change the floor and the direction of an elevator,
+  without the intervention of a user pressing the pick-up button. It could be the equivalent to an engineer
+  using his master key when they are fixing an elevator in a building.
+
+  The direction can be UP, DOWN or STOPPED, and the elevator is left IDLE in the floor, ready to go on with its
+  tasks in the next tick. The engineer can also take the elevator out of service with the OUT_OF_SERVICE direction.
+  Any other direction is rejected, as well as moving an elevator whose doors are not closed
+*/
+func (control *elevatorControlSystem) Update(elevatorID int, floor int, direction string) error {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	elev, err := control.getElevator(elevatorID)
+	if err != nil {
+		return err
+	}
+	if Direction(direction) == OUT_OF_SERVICE {
+		return elev.SetState(OutOfService)
+	}
+
+	newDirection, err := parseDirection(direction)
+	if err != nil {
+		return err
+	}
+	if err := control.checkFloor(floor); err != nil {
+		return err
+	}
+	if err := elev.SetState(Idle); err != nil {
+		return err
+	}
+	elev.SetFloorNumber(floor)
+	elev.SetDirection(newDirection)
+	return nil
+}
+
+/**
+* For the sake of simplicity, let's assume that a PickUpButtonWasPushed action is composed of the following actions:
+
+  1) A user located in pickUpFloor pushes the button to call an elevator
+  2) When one of the elevators arrives to the pickUpFloor, the user enters and pushes the button corresponding
+     to his desired dropOffFloor
+
+  Both floors must exist in the building, and they must be different. If all the elevators in service are full,
+  or none of them is in service, the user keeps waiting in the floor until one of them can take him.
+
+  It tells which elevator will take the user, and how long it will take to arrive to the pick-up floor
+*/
+func (control *elevatorControlSystem) PickUpButtonWasPushed(userID string, pickUpFloor int, dropOffFloor int) (Assignment, error) {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	return control.pickUp(userID, pickUpFloor, dropOffFloor)
+}
+
+// Registers the pick-up request of a user right now. The caller must hold the mutex of the system
+func (control *elevatorControlSystem) pickUp(userID string, pickUpFloor int, dropOffFloor int) (Assignment, error) {
+	if err := control.checkTrip(pickUpFloor, dropOffFloor); err != nil {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA}, fmt.Errorf("%v: %w", userID, err)
+	}
+
+	// Choose the most optimal elevator and assign it this the current pick-up task, defined by the parameters
+	request := PickUpRequest{At: control.clock, UserID: userID, PickUpFloor: pickUpFloor, DropOffFloor: dropOffFloor, TripID: control.newTripID()}
+	control.startJourney(request)
+	assignment, dispatched := control.dispatch(request)
+	if !dispatched {
+		control.schedule(request)
+	}
+	return assignment, nil
+}
+
+/**
+ *	A user located in a floor pushes the button to call an elevator going up or down, like in the buildings where
+	the users push the button of the floor they want to go once they are in the elevator, with CarCall. The hall
+	call is answered once the chosen elevator opens its doors in the floor.
+
+	The floor must exist in the building, and the direction must be UP or DOWN. There's no UP button in the top
+	floor, nor DOWN button in the ground floor. It tells which elevator will answer the call, and when
+	@ floor int
+	@ direction Direction
+*/
+func (control *elevatorControlSystem) HallCall(floor int, direction Direction) (Assignment, error) {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	if err := control.checkFloor(floor); err != nil {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA}, err
+	}
+	if direction != UP && direction != DOWN ||
+		direction == UP && floor == control.TOPFLOOR || direction == DOWN && floor == 0 {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA},
+			fmt.Errorf("%w: there's no %v button in floor %d", ErrInvalidDirection, direction, floor)
+	}
+
+	request := PickUpRequest{
+		At:                control.clock,
+		UserID:            fmt.Sprintf("Hall call %v", direction),
+		PickUpFloor:       floor,
+		DropOffFloor:      UnknownFloor,
+		HallCallDirection: direction,
+		TripID:            control.newTripID(),
+	}
+	assignment, dispatched := control.dispatch(request)
+	if !dispatched {
+		control.schedule(request)
+	}
+	return assignment, nil
+}
+
+/**
+ *	A user in an elevator pushes the button of the floor he wants to go. The elevator will stop in that floor
+	@ elevatorID int
+	@ floor int
+*/
+func (control *elevatorControlSystem) CarCall(elevatorID int, floor int) error {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	elev, err := control.getElevator(elevatorID)
+	if err != nil {
+		return err
+	}
+	if err := control.checkFloor(floor); err != nil {
+		return err
+	}
+
+	// Pushing twice the same button doesn't make any difference
+	for _, trip := range elev.GetAssignedTrips() {
+		if trip.Call == CarButtonCall && trip.ToFloor == floor {
+			return nil
+		}
+	}
+	elev.AssignTrip(TripDetails{
+		TripID:        control.newTripID(),
+		UserID:        fmt.Sprintf("Car call to floor %d", floor),
+		Call:          CarButtonCall,
+		Event:         CarCallRegistered,
+		ElevatorFloor: elev.GetFloorNumber(),
+		FromFloor:     elev.GetFloorNumber(),
+		ToFloor:       floor,
+		TripDirection: elev.GetDirection(),
+		CalledAt:      control.clock,
+	})
+	return nil
+}
+
+/**
+ *	Registers a pick-up request that a user will do at the simulated time 'at'. The request is not assigned
+	to an elevator until the simulation reaches that time, so it is dispatched attending to where the
+	elevators really are at that moment. Requests scheduled in the past are dispatched immediately.
+	The floors are checked when the request is scheduled, so the errors are the same of PickUpButtonWasPushed
+	@ at int
+	@ userID string
+	@ pickUpFloor int
+	@ dropOffFloor int
+*/
+func (control *elevatorControlSystem) SchedulePickUp(at int, userID string, pickUpFloor int, dropOffFloor int) error {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	if at <= control.clock {
+		_, err := control.pickUp(userID, pickUpFloor, dropOffFloor)
+		return err
+	}
+	if err := control.checkTrip(pickUpFloor, dropOffFloor); err != nil {
+		return fmt.Errorf("%v: %w", userID, err)
+	}
+
+	request := PickUpRequest{At: at, UserID: userID, PickUpFloor: pickUpFloor, DropOffFloor: dropOffFloor, TripID: control.newTripID()}
+	control.startJourney(request)
+	control.schedule(request)
+	return nil
+}
+
+/**
+ *  Builds the step list of actions performed by the elevators to complete the tasks they have been
+	assigned by the control system and calls PrintStepListSimulation() once it is ready in order to to
+    print it.
+
+	The elevators are moved all together, one time unit at a time, until none of them has pending trips
+	and there are no more scheduled pick-up requests. The system is not locked between the ticks, so other
+	goroutines can go on pushing buttons while the elevators move. It fails with ErrAlreadyRunning while the
+	system runs in real time, since its clock moves the elevators
+*/
+func (control *elevatorControlSystem) Step() error {
+	if err := control.RunUntilIdle(context.Background()); err != nil {
+		return err
+	}
+	control.printStepListSimulation()
+	return nil
+}
+
+/**
+ *	Moves the elevators all together, one time unit at a time, until none of them has pending trips and there are
+	no more scheduled pick-up requests, like Step, without printing the step list. It stops when the context is
+	done, telling why, and it fails with ErrAlreadyRunning while the system runs in real time
+	@ ctx context.Context
+*/
+func (control *elevatorControlSystem) RunUntilIdle(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ticked, err := control.tickIfThereArePendingTrips()
+		if err != nil || !ticked {
+			return err
+		}
+	}
+}
+
+/**
+ *	Moves every elevator of the system by exactly one time unit. The elevators are moved in lockstep,
+	so all the steps taken during this tick are stamped with the same simulated time. It fails with
+	ErrAlreadyRunning while the system runs in real time
+*/
+func (control *elevatorControlSystem) Tick() error {
+	control.mutex.Lock()
+	if control.running {
+		control.mutex.Unlock()
+		return ErrAlreadyRunning
+	}
+	control.publish(control.tick())
+	return nil
+}
+
+/**
+ *	Moves every elevator of the system by the given amount of time units. It fails with ErrAlreadyRunning while
+	the system runs in real time
+	@ timeUnits int
+*/
+func (control *elevatorControlSystem) Advance(timeUnits int) error {
+	for i := 0; i < timeUnits; i++ {
+		if err := control.Tick(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ *	Tells the simulated time of the system, which is the number of ticks performed so far
+*/
+func (control *elevatorControlSystem) Now() int {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	return control.clock
+}
+
+/**
+ *	Tells which trips the elevators were not able to complete, one StuckTripError per trip, naming the elevator,
+	the user and the floors of the trip
+*/
+func (control *elevatorControlSystem) Failures() []error {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	failures := []error{}
+	for i := range control.Elevators {
+		failures = append(failures, control.Elevators[i].GetFailures()...)
+	}
+	return failures
+}
+
+/**
+ *	Tells the state of an elevator of the system, making sure it exists. Like the Snapshot, it is a copy, so it
+	doesn't change when the simulation goes on, and changing it doesn't change the elevator
+	@ elevatorID int
+*/
+func (control *elevatorControlSystem) GetElevator(elevatorID int) (CarStatus, error) {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	if _, err := control.getElevator(elevatorID); err != nil {
+		return CarStatus{}, err
+	}
+	return control.carStatus(elevatorID), nil
+}
+
+/***** ELEVATOR CONTROL SYSTEM HELPER FUNCTIONS, NOT EXPOSED IN THE INTERFACE *************/
+// Gets an elevator of the system, making sure it exists
+func (control *elevatorControlSystem) getElevator(elevatorID int) (Elevator, error) {
+	if elevatorID < 0 || elevatorID >= len(control.Elevators) {
+		return nil, fmt.Errorf("%w: elevator %d is not between 0 and %d", ErrUnknownElevator, elevatorID, len(control.Elevators)-1)
+	}
+	return control.Elevators[elevatorID], nil
+}
+
+// Makes sure the floor exists in the building
+func (control *elevatorControlSystem) checkFloor(floor int) error {
+	if floor < 0 || floor > control.TOPFLOOR {
+		return fmt.Errorf("%w: floor %d is not between 0 and %d", ErrFloorOutOfRange, floor, control.TOPFLOOR)
+	}
+	return nil
+}
+
+// Makes sure both floors of a trip exist in the building, and that the trip goes somewhere
+func (control *elevatorControlSystem) checkTrip(pickUpFloor int, dropOffFloor int) error {
+	if err := control.checkFloor(pickUpFloor); err != nil {
+		return err
+	}
+	if err := control.checkFloor(dropOffFloor); err != nil {
+		return err
+	}
+	if pickUpFloor == dropOffFloor {
+		return fmt.Errorf("%w: floor %d", ErrSameFloor, pickUpFloor)
+	}
+	return nil
+}
+
+// Tells if any of the elevators still has trips to complete
+func (control *elevatorControlSystem) hasPendingTrips() bool {
+	for i := range control.Elevators {
+		if len(control.Elevators[i].GetAssignedTrips()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ *	Tells if ticking would move the system on: an elevator still has trips, a pick-up request is still to come, or
+	a request is waiting for an elevator while some of them is in service. The requests waiting while all the
+	elevators are out of service keep queued, but there's nothing to tick for until one of them is back in service
+*/
+func (control *elevatorControlSystem) hasWorkToDo() bool {
+	if control.hasPendingTrips() {
+		return true
+	}
+	for _, request := range control.scheduled {
+		if request.At > control.clock || control.hasElevatorsInService() {
+			return true
+		}
+	}
+	return false
+}
+
+// Tells if any of the elevators is in service
+func (control *elevatorControlSystem) hasElevatorsInService() bool {
+	for i := range control.Elevators {
+		if control.Elevators[i].GetState() != OutOfService {
+			return true
+		}
+	}
+	return false
+}
+
+// Gives an ID to a new trip, so it is told apart from the other trips even if they go between the same floors
+func (control *elevatorControlSystem) newTripID() int {
+	control.lastTripID++
+	return control.lastTripID
+}
+
+// Keeps the scheduled requests sorted by time, and in arrival order when they happen at the same time
+func (control *elevatorControlSystem) schedule(request PickUpRequest) {
+	position := len(control.scheduled)
+	for position > 0 && control.scheduled[position-1].At > request.At {
+		position--
+	}
+	control.scheduled = append(control.scheduled, PickUpRequest{})
+	copy(control.scheduled[position+1:], control.scheduled[position:])
+	control.scheduled[position] = request
+}
+
+// Dispatches the scheduled pick-up requests whose time has come. The ones that can't be assigned to
+// an elevator because all of them are full keep waiting for the next tick
+func (control *elevatorControlSystem) dispatchScheduledCalls() {
+	waiting := []PickUpRequest{}
+	for _, due := range control.scheduled {
+		if due.At > control.clock {
+			waiting = append(waiting, due)
+		} else if _, dispatched := control.dispatch(due); !dispatched {
+			waiting = append(waiting, due)
+		}
+	}
+	control.scheduled = waiting
+}
+
+// Asks the dispatcher which elevator will take the user, and assigns it the trip. It tells
+// if the trip has been assigned, or the user has to keep waiting
+func (control *elevatorControlSystem) dispatch(request PickUpRequest) (Assignment, bool) {
+	assignment := control.dispatcher.Dispatch(control.Elevators, request)
+	if assignment.ElevatorID < 0 || assignment.ElevatorID >= len(control.Elevators) {
+		return Assignment{ElevatorID: NoElevator, ETA: UnknownETA}, false
+	}
+
+	newTrip := request.trip()
+	newTrip.ElevatorFloor = control.Elevators[assignment.ElevatorID].GetFloorNumber()
+	control.Elevators[assignment.ElevatorID].AssignTrip(newTrip)
+	control.assignJourney(request, assignment.ElevatorID)
+	return assignment, true
+}
+
+/**
+ *	Moves every elevator of the system by one time unit, and tells the events that happened meanwhile.
+	The caller must hold the mutex of the system
+*/
+func (control *elevatorControlSystem) tick() []Event {
+	return control.tickWith(control.moveTheElevators)
+}
+
+/**
+ *	Moves every elevator of the system by one time unit with move, which tells the events of the elevators in the
+	order of the elevators. The simulation and the real time mode only differ in how the elevators are moved.
+	The caller must hold the mutex of the system
+	@ move func(now int) []Event
+*/
+func (control *elevatorControlSystem) tickWith(move func(now int) []Event) []Event {
+	control.dispatchScheduledCalls()
+	events := move(control.clock)
+	control.followTheJourneys()
+	control.clock++
+	return events
+}
+
+// Moves the elevators one after the other
+func (control *elevatorControlSystem) moveTheElevators(now int) []Event {
+	events := []Event{}
+	for i := range control.Elevators {
+		control.Elevators[i].Tick(now)
+		events = append(events, control.Elevators[i].TakeEvents()...)
+	}
+	return events
+}
+
+// Moves the elevators by one time unit if any of them has work to do, and tells if it did
+func (control *elevatorControlSystem) tickIfThereArePendingTrips() (bool, error) {
+	control.mutex.Lock()
+	if control.running {
+		control.mutex.Unlock()
+		return false, ErrAlreadyRunning
+	}
+	if !control.hasWorkToDo() {
+		control.mutex.Unlock()
+		return false, nil
+	}
+	control.publish(control.tick())
+	return true, nil
+}
+
+/**
+ *	Unlocks the system and publishes the events of a tick to the subscribers, so they can call the system back
+	without a deadlock. The events of a tick are queued before the system is unlocked, so they are published
+	before the ones of the next tick. The caller must hold the mutex of the system
+	@ events []Event
+*/
+func (control *elevatorControlSystem) publish(events []Event) {
+	control.bus.enqueue(events)
+	control.mutex.Unlock()
+	control.bus.deliver()
+}
+
+func (control *elevatorControlSystem) printStepListSimulation() {
+	StepListTextRenderer{}.Render(os.Stdout, control.Snapshot())
+}
+
+/***** END OF THE ELEVATOR CONTROL SYSTEM HELPER FUNCTIONS, NOT EXPOSED IN THE INTERFACE *************/
+
+/****************************************************************
+ ****************************************************************
+ ***                                                          ***
+ ***                 ELEVATOR FUNCIONALITY                    ***
+ ***                                                          ***
+ ****************************************************************
+ *****************************************************************/
+// Direction of an elevator, or of the trip requested by a user
+type Direction string
+
+// Going to the upper floors
+const UP Direction = "UP"
+
+// Going to the lower floors
+const DOWN Direction = "DOWN"
+
+// Not going anywhere, or a trip of a user whose destination is not known yet
+const STOPPED Direction = "STOPPED"
+
+// Special value accepted by Update() as direction, used by engineers to take an elevator out of service. It's not
+// a direction the elevators move in
+const OUT_OF_SERVICE Direction = "OUT_OF_SERVICE"
+
+// Default capacity of the elevators, the usual one for 8 persons of a residential building
+const defaultCapacity = 8
+const defaultRatedLoad = 630
+
+// Kilograms every user is assumed to weigh
+const averageUserWeight = 75
+
+// Time units an elevator needs to open its doors, let the users in and out, and close them again
+const doorsCycleTime = 3
+
+// Floor where a user wants to go when he has not told it yet
+const UnknownFloor = -1
+
+// Kind of request that made an elevator do a trip
+type CallKind int
+
+const (
+	PassengerCall  CallKind = iota // A user pushed the pick-up button telling where he wants to go
+	HallButtonCall                 // A user pushed the up or down button in a floor
+	CarButtonCall                  // A user pushed the button of a floor inside the elevator
+)
+
+var callKindNames = map[CallKind]string{
+	PassengerCall:  "PASSENGER",
+	HallButtonCall: "HALL",
+	CarButtonCall:  "CAR",
+}
+
+func (kind CallKind) String() string {
+	if name, ok := callKindNames[kind]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// Tells what an elevator is doing in a given moment
+type ElevatorState int
+
+const (
+	Idle ElevatorState = iota
+	MovingUp
+	MovingDown
+	DoorsOpening
+	DoorsOpen
+	DoorsClosing
+	OutOfService
+)
+
+var elevatorStateNames = map[ElevatorState]string{
+	Idle:         "IDLE",
+	MovingUp:     "MOVING UP",
+	MovingDown:   "MOVING DOWN",
+	DoorsOpening: "OPENING DOORS",
+	DoorsOpen:    "DOORS OPEN",
+	DoorsClosing: "CLOSING DOORS",
+	OutOfService: "OUT OF SERVICE",
+}
+
+// Which states an elevator can go to from every state. An elevator never moves with the doors open,
+// and an engineer can take an elevator out of service at any moment
+var allowedStateTransitions = map[ElevatorState][]ElevatorState{
+	Idle:         {Idle, MovingUp, MovingDown, DoorsOpening, OutOfService},
+	MovingUp:     {Idle, MovingUp, MovingDown, DoorsOpening, OutOfService},
+	MovingDown:   {Idle, MovingUp, MovingDown, DoorsOpening, OutOfService},
+	DoorsOpening: {DoorsOpen, OutOfService},
+	DoorsOpen:    {DoorsClosing, OutOfService},
+	DoorsClosing: {Idle, MovingUp, MovingDown, DoorsOpening, OutOfService},
+	OutOfService: {Idle, OutOfService},
+}
+
+func (state ElevatorState) String() string {
+	if name, ok := elevatorStateNames[state]; ok {
+		return name
+	}
+	return fmt.Sprintf("ElevatorState(%d)", int(state))
+}
+
+// Tells if an elevator in this state is allowed to go to the next one
+func (state ElevatorState) canChangeTo(next ElevatorState) bool {
+	for _, allowed := range allowedStateTransitions[state] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Describes the movement of an elevator going in this direction
+func (direction Direction) describe() string {
+	if direction == STOPPED {
+		return "stopped"
+	}
+	return fmt.Sprintf("going %v", direction)
+}
+
+// Converts the direction received from the outside of the system, rejecting the unknown ones
+func parseDirection(direction string) (Direction, error) {
+	switch Direction(direction) {
+	case UP, DOWN, STOPPED:
+		return Direction(direction), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidDirection, direction)
+}
+
+/**
+ *	Every elevator of the fleet: where it is, its state machine, the trips assigned to it and the steps it took to
+	complete them. The elevators are created by the system with NewElevator, and they are moved by it tick by tick.
+	The trips, steps and failures returned by the getters are copies, so changing them doesn't change the elevator
+*/
+type Elevator interface {
+	GoToNextFloorInElevatorsTaskList() int
+	Tick(now int)
+	// ELEVATOR GETTERS AND SETTERS
+	GetFloorNumber() int
+	SetFloorNumber(floorNumber int)
+	GetDirection() Direction
+	SetDirection(direction Direction)
+	GetState() ElevatorState
+	SetState(state ElevatorState) error
+	GetStepList() StepList
+	GetStep(step int) TripDetails
+	GetNumberOfSteps() int
+	GetAssignedTrips() TripQueue
+	GetAssignedTrip(trip int) TripDetails
+	AssignTrip(details TripDetails)
+	GetFailures() []error
+	GetLoad() (persons int, kilograms int)
+	SetCapacity(persons int, ratedLoad int)
+	HasRoomForAnotherTrip(weight int) bool
+	SetMovementPolicy(policy MovementPolicy)
+	EstimateTimeToPickUp(trip TripDetails) int
+	EstimateMarginalEnergy(trip TripDetails) float64
+	SetEnergyModel(model EnergyModel)
+	GetOdometer() Odometer
+	TakeEvents() []Event
+	// END OF ELEVATOR GETTERS AND SETTERS
+}
+
+// Stores the information about the current status of an elevator
+type elevator struct {
+	elevID        int       // 0..NUMELEVATORS
+	floorNumber   int       // 0..TOPFLOOR: which floor is the elevator in
+	topFloor      int       // Top floor of the building
+	direction     Direction // Up, Down, Stopped
+	state         ElevatorState
+	policy        MovementPolicy
+	capacity      int       // Maximum number of persons in the elevator
+	ratedLoad     int       // Maximum load of the elevator, in kilograms
+	assignedTrips TripQueue // Queue of assignedTrips assigned to an elevator
+	stepList      StepList  // List of steps performed by this Elevator to complete his assignedTrips
+	noProgress    int       // Time units since a user got into or out of the elevator, or it had nothing to do
+	noProgressMax int       // Time units without progress after which the assigned trips are failed
+	failures      []error   // Trips the elevator was not able to complete
+	odometer      Odometer  // What the elevator has done since the system was started
+	energyModel   EnergyModel
+	events        []Event // Events of the elevator and its trips not published yet
+}
+
+// Trips assigned to an elevator and not completed yet, in the order they were assigned
+type TripQueue []TripDetails
+
+// Steps an elevator took to complete its trips, in the order they happened. Every step is the trip it was taken for,
+// with the time, the floor and the direction of the elevator at that moment
+type StepList []TripDetails
+
+// Stores the state of an elevator trip
+type TripDetails struct {
+	TripID            int       // Unique ID of the trip in the system, given when it was requested
+	UserID            string    // Not necessary, but added for debugging and tracing purposes
+	Event             EventKind // Last thing that happened to the trip: Assigned, Boarded, Alighted...
+	ElevatorFloor     int       // Floor of the elevator when it took this step
+	ElevatorDirection Direction // Direction of the elevator when it took this step
+	Time              int       // Simulated time when the elevator took this step
+	CalledAt          int       // Simulated time when the user called the elevator
+	FromFloor         int       // Floor where the user presses the pick-up button (0..TOPFLOOR)
+	ToFloor           int       // Floor where the user wants to go (0..TOPFLOOR), UnknownFloor for the hall calls
+	TripDirection     Direction // Up, Down, Stopped
+	Weight            int       // Kilograms of the user
+	Call              CallKind  // Passenger, hall or car call
+}
+
+/**
+ *	Creates an elevator idle in the ground floor of a building, stopped, with the default capacity and energy
+	model, and without trips. Its trips are failed if it makes no progress for twice the time to go up and down
+	the whole building
+	@ i int: ID of the elevator in the system
+	@ topFloor int
+*/
+func NewElevator(i int, topFloor int) Elevator {
+	return &elevator{
+		elevID:        i,
+		floorNumber:   0,
+		topFloor:      topFloor,
+		direction:     STOPPED,
+		state:         Idle,
+		capacity:      defaultCapacity,
+		ratedLoad:     defaultRatedLoad,
+		assignedTrips: make(TripQueue, 0),
+		stepList:      make(StepList, 0),
+		energyModel:   DefaultEnergyModel(),
+		// Twice the time to travel the whole building up and down, stopping at the ends
+		noProgressMax: 2 * (2*topFloor + 2*doorsCycleTime),
+	}
+}
+
+/*
+  The elevator will go to the nearest drop-down floor found in the list of his assigned tasks.
+  This task is required to be in the same direction as the elevator is currently going.
+*/
+func (elev *elevator) GoToNextFloorInElevatorsTaskList() int {
+	minDistance := 9999
+	chosenFloor := elev.floorNumber
+	aUserAskedToStopInThisFloor := 9999
+	nearestFloorWhereAnUserIsWaitingIn := 9999
+
+	// Default behavior: move the elevator to the next floor in the same direction that it is currently moving
+	if elev.direction == UP {
+		chosenFloor++
+	} else {
+		chosenFloor--
+	}
+
+	// Improvement: search all the assigned trips in the same direction and find ...
+	for i := range elev.assignedTrips {
+		trip := elev.assignedTrips[i]
+		distance := trip.FromFloor - elev.floorNumber
+		// ... the nearest assigned trip
+		// where a user is waiting for an elevator, and whose trip direction matches the elevator direction
+		if distance > 0 &&
+			distance < minDistance &&
+			trip.Event.isWaiting() {
+			nearestFloorWhereAnUserIsWaitingIn = trip.FromFloor
+			minDistance = distance
+		}
+		// ... if there's someone the elevator who wants to stop before, do it
+		for i := elev.floorNumber; i < nearestFloorWhereAnUserIsWaitingIn; i++ {
+			if i == trip.ToFloor && trip.Event.isOnBoard() {
+				aUserAskedToStopInThisFloor = trip.ToFloor
+			}
+		}
+	}
+
+	// If some of the improved searches have found a better option than going one by one, jump to the best of them instead of going floor by floor
+	if nearestFloorWhereAnUserIsWaitingIn < 9999 || aUserAskedToStopInThisFloor < 9999 {
+		// Jump to the nearest floor where there's either an user wants a pick-up and goes in the same direction of the elevator, or
+		// an user in the elevator who directly wants to stop in
+		if aUserAskedToStopInThisFloor < nearestFloorWhereAnUserIsWaitingIn {
+			chosenFloor = aUserAskedToStopInThisFloor
+			// If there's not an intermediate stop, jump to the nearest floor where someone is waitint for a pick-up in the same direction
+		} else {
+			chosenFloor = nearestFloorWhereAnUserIsWaitingIn
+		}
+	}
+
+	return chosenFloor
+}
+
+/**
+ * 	Moves the elevator by exactly one time unit and adds to its step list the actions performed in it.
+	Every tick the elevator goes through one of the transitions of its state machine:
+
+	- IDLE or MOVING: if some user wants to get into or out of the elevator in this floor, it starts opening
+	  the doors. Otherwise it moves one floor towards the next floor in its task list, or stays IDLE if
+	  it doesn't have more tasks
+	- OPENING DOORS: the doors get open, and the users step-out and get into the elevator
+	- DOORS OPEN: the doors start closing
+	- CLOSING DOORS: the same as IDLE or MOVING, an user arriving at the last moment can open the doors again
+	- OUT OF SERVICE: the elevator doesn't move until an engineer puts it back in service
+
+	@ now int: simulated time of this tick
+*/
+func (elev *elevator) Tick(now int) {
+	defer elev.watchLiveness(now)
+
+	if elev.state == OutOfService {
+		return
+	}
+
+	elev.takeNoteOfTheNewTrips(now)
+	floorBefore, stateBefore, busy := elev.floorNumber, elev.state, len(elev.assignedTrips) > 0 || elev.state != Idle
+
+	switch elev.state {
+	case DoorsOpening:
+		elev.changeState(DoorsOpen)
+		elev.emit(DoorsOpened, now)
+		elev.exchangeUsersInThisFloor(now)
+	case DoorsOpen:
+		elev.changeState(DoorsClosing)
+	default:
+		if stateBefore == DoorsClosing {
+			elev.emit(DoorsClosed, now)
+		}
+		elev.moveToTheNextFloor()
+		if elev.floorNumber != floorBefore {
+			elev.emit(CarArrived, now)
+		}
+	}
+
+	elev.odometer.takeNoteOfTheTick(floorBefore, elev.floorNumber, stateBefore, elev.state, busy)
+	_, load := elev.GetLoad()
+	elev.odometer.Energy += elev.energyModel.energyOfTheTick(elev.floorNumber-floorBefore, stateBefore != elev.state, load, elev.ratedLoad)
+}
+
+/**
+ *	Leaves the elevator in the floor if some user has to get in or out in it, otherwise moves the elevator one
+	floor in the direction of its next task
+*/
+func (elev *elevator) moveToTheNextFloor() {
+	if !noMoreUsersToStepOutInThisFloor(elev.assignedTrips, elev.floorNumber) || elev.someoneCanGetInInThisFloor() {
+		elev.changeState(DoorsOpening)
+		return
+	}
+
+	// If the elevator has not assigned trips, it stays stopped where it is
+	if len(elev.assignedTrips) == 0 {
+		elev.direction = STOPPED
+		elev.changeState(Idle)
+		return
+	}
+
+	// A stopped elevator starts moving towards its nearest task
+	if elev.direction == STOPPED {
+		elev.direction = elev.directionOfTheNearestTask()
+	}
+
+	previousFloor := elev.floorNumber
+	switch elev.policy {
+	case LOOK:
+		elev.lookAhead()
+	case SCAN:
+		elev.scanTheBuilding()
+	default:
+		elev.followTheTaskList()
+	}
+
+	if elev.floorNumber > previousFloor {
+		elev.changeState(MovingUp)
+	} else if elev.floorNumber < previousFloor {
+		elev.changeState(MovingDown)
+	} else {
+		elev.changeState(Idle)
+	}
+}
+
+/**
+ *	Drops-off the users who wanted to go to this floor and picks-up the ones waiting in it, taking note of it
+	in the step list
+*/
+func (elev *elevator) exchangeUsersInThisFloor(now int) {
+	for i := 0; i < len(elev.assignedTrips); i++ {
+		trip := &elev.assignedTrips[i]
+		if trip.ToFloor == elev.floorNumber && trip.Event.isOnBoard() {
+			trip.Event = Alighted
+			if trip.Call == CarButtonCall {
+				trip.Event = CarCallServed
+			}
+			elev.takeNoteOfTheStep(trip, now)
+			elev.noProgress = 0
+			elev.assignedTrips = removeAssignedTrip(elev.assignedTrips, i)
+			i--
+		}
+	}
+	// A full elevator leaves the rest of the users waiting in the floor, it will come back for them later.
+	// The hall calls are answered once the elevator opens the doors, the users in the floor get in and push
+	// the buttons of the floors they want to go
+	for i := 0; i < len(elev.assignedTrips); i++ {
+		trip := &elev.assignedTrips[i]
+		if trip.FromFloor == elev.floorNumber && trip.Event.isWaiting() && elev.hasRoomFor(trip.Weight) {
+			elev.noProgress = 0
+			if trip.Call == HallButtonCall {
+				trip.Event = HallCallAnswered
+				elev.takeNoteOfTheStep(trip, now)
+				elev.assignedTrips = removeAssignedTrip(elev.assignedTrips, i)
+				i--
+				continue
+			}
+			trip.Event = Boarded
+			elev.takeNoteOfTheStep(trip, now)
+		}
+	}
+}
+
+// Adds to the step list the trips assigned to this elevator since the last tick
+func (elev *elevator) takeNoteOfTheNewTrips(now int) {
+	for i := range elev.assignedTrips {
+		trip := &elev.assignedTrips[i]
+		if trip.Event.registersATrip() && !elev.tookNoteOfTheTrip(trip.TripID) {
+			elev.takeNoteOfTheStep(trip, now)
+		}
+	}
+}
+
+/**
+ *	Updates the trip info with the elevator's situation, and adds it to the step list. Every change of the trip's
+	event happens once, so every call is a new step, even when the same user repeats the same trip
+*/
+func (elev *elevator) takeNoteOfTheStep(trip *TripDetails, now int) {
+	trip.ElevatorFloor = elev.floorNumber
+	trip.ElevatorDirection = elev.direction
+	trip.Time = now
+	elev.stepList = append(elev.stepList, *trip)
+	elev.events = append(elev.events, stepEvent(elev.elevID, *trip))
+}
+
+// Tells if there's already a step of this trip in the step list
+func (elev *elevator) tookNoteOfTheTrip(tripID int) bool {
+	for i := range elev.stepList {
+		if elev.stepList[i].TripID == tripID {
+			return true
+		}
+	}
+	return false
+}
+
+// Takes note of an event of the elevator, that is not a step of its trips
+func (elev *elevator) emit(kind EventKind, now int) {
+	elev.events = append(elev.events, Event{
+		Kind:       kind,
+		Time:       now,
+		ElevatorID: elev.elevID,
+		Floor:      elev.floorNumber,
+		Direction:  elev.direction,
+		FromFloor:  UnknownFloor,
+		ToFloor:    UnknownFloor,
+	})
+}
+
+/**
+ *	Liveness guard of the elevator: if it has been too long without anybody getting into or out of it, the
+	elevator is not going to complete its trips, i.e. because a trip goes to an unreachable floor or the
+	elevator is out of service. The pending trips are failed, so that the rest of the system can go on
+*/
+func (elev *elevator) watchLiveness(now int) {
+	if len(elev.assignedTrips) == 0 {
+		elev.noProgress = 0
+		return
+	}
+	elev.noProgress++
+	if elev.noProgress <= elev.noProgressMax {
+		return
+	}
+
+	for i := range elev.assignedTrips {
+		trip := elev.assignedTrips[i]
+		elev.failures = append(elev.failures, &StuckTripError{
+			TripID:        trip.TripID,
+			ElevatorID:    elev.elevID,
+			ElevatorFloor: elev.floorNumber,
+			UserID:        trip.UserID,
+			FromFloor:     trip.FromFloor,
+			ToFloor:       trip.ToFloor,
+			InElevator:    trip.Event.isOnBoard(),
+			Since:         now - elev.noProgress + 1,
+			Time:          now,
+		})
+	}
+	elev.assignedTrips = make(TripQueue, 0)
+	elev.noProgress = 0
+}
+
+/**
+ *	Tells how many time units the elevator would need to pick-up the user of this trip, if it was assigned to it
+	now. It is estimated replaying the trips assigned to the elevator, with this one, in a copy of the elevator:
+	the time to travel to every floor and to open and close the doors in every stop are taken into account.
+	It is UnknownETA if the elevator would not pick-up the user
+*/
+func (elev *elevator) EstimateTimeToPickUp(trip TripDetails) int {
+	trip.TripID = estimatedTripID
+	ghost := elev.ghost(trip)
+	for now := 0; len(ghost.failures) == 0; now++ {
+		ghost.Tick(now)
+		if !ghost.isWaitingFor(trip) {
+			return now
+		}
+	}
+	return UnknownETA
+}
+
+// Copy of the elevator, with the trips assigned to it and these new ones, to estimate what it would do with them
+func (elev *elevator) ghost(newTrips ...TripDetails) *elevator {
+	ghost := *elev
+	ghost.assignedTrips = append(append(TripQueue{}, elev.assignedTrips...), newTrips...)
+	ghost.stepList = nil
+	ghost.failures = nil
+	ghost.events = nil
+	return &ghost
+}
+
+// ID of the trip whose pick-up time is estimated, told apart from the trips already assigned to the elevator
+const estimatedTripID = -1
+
+// Tells if the user of this trip is still waiting for the elevator in the floor
+func (elev *elevator) isWaitingFor(trip TripDetails) bool {
+	for i := range elev.assignedTrips {
+		if elev.assignedTrips[i].TripID == trip.TripID && elev.assignedTrips[i].Event.isWaiting() {
+			return true
+		}
+	}
+	return false
+}
+
+// Changes the state of the elevator during its normal operation, where only valid transitions can happen
+func (elev *elevator) changeState(state ElevatorState) {
+	if err := elev.SetState(state); err != nil {
+		panic(fmt.Sprintf("elevator %d: %v", elev.elevID, err))
+	}
+}
+
+// Tells why a trip was failed by the liveness guard of an elevator
+type StuckTripError struct {
+	TripID        int    // Trip that was failed
+	ElevatorID    int    // Elevator the trip was assigned to
+	ElevatorFloor int    // Floor where the elevator was when the trip was failed
+	UserID        string // User who requested the trip
+	FromFloor     int    // Floor where the user pushed the pick-up button
+	ToFloor       int    // Floor where the user wanted to go
+	InElevator    bool   // The user was already in the elevator, instead of waiting in a floor
+	Since         int    // Last time the elevator made any progress
+	Time          int    // Time when the trip was failed
+}
+
+// All the stuck trips are ErrNoProgress errors
+var ErrNoProgress = errors.New("elevator made no progress")
+
+func (err *StuckTripError) Error() string {
+	trip := fmt.Sprintf("waiting in floor %d to go to floor %d", err.FromFloor, err.ToFloor)
+	if err.ToFloor == UnknownFloor {
+		trip = fmt.Sprintf("waiting in floor %d", err.FromFloor)
+	}
+	if err.InElevator {
+		trip = fmt.Sprintf("in the elevator, going from floor %d to floor %d", err.FromFloor, err.ToFloor)
+	}
+	return fmt.Sprintf("elevator %d made no progress from t=%d to t=%d in floor %d: %v was %v",
+		err.ElevatorID, err.Since, err.Time, err.ElevatorFloor, err.UserID, trip)
+}
+
+func (err *StuckTripError) Unwrap() error {
+	return ErrNoProgress
+}
+
+/************** ELEVATOR INTERFACE GETTERS AND SETTERS *************/
+func (elev *elevator) AssignTrip(details TripDetails) {
+	elev.assignedTrips = append(elev.assignedTrips, details)
+}
+
+func (elev *elevator) GetAssignedTrip(tripNumber int) TripDetails {
+	return elev.assignedTrips[tripNumber]
+}
+
+func (elev *elevator) GetDirection() Direction {
+	return elev.direction
+}
+
+func (elev *elevator) GetState() ElevatorState {
+	return elev.state
+}
+
+// Only the transitions allowed by the elevator state machine are accepted
+func (elev *elevator) SetState(state ElevatorState) error {
+	if !elev.state.canChangeTo(state) {
+		return fmt.Errorf("%w: an elevator can't go from %v to %v", ErrInvalidStateChange, elev.state, state)
+	}
+	elev.state = state
+	return nil
+}
+
+func (elev *elevator) GetStepList() StepList {
+	return append(StepList{}, elev.stepList...)
+}
+
+func (elev *elevator) GetStep(stepNumber int) TripDetails {
+	return elev.stepList[stepNumber]
+}
+
+func (elev *elevator) GetNumberOfSteps() int {
+	return len(elev.stepList)
+}
+
+func (elev *elevator) SetFloorNumber(floorNumber int) {
+	elev.floorNumber = floorNumber
+}
+
+func (elev *elevator) SetDirection(direction Direction) {
+	elev.direction = direction
+}
+
+func (elev *elevator) GetFloorNumber() int {
+	return elev.floorNumber
+}
+
+func (elev *elevator) GetAssignedTrips() TripQueue {
+	return append(TripQueue{}, elev.assignedTrips...)
+}
+
+func (elev *elevator) GetFailures() []error {
+	return append([]error{}, elev.failures...)
+}
+
+func (elev *elevator) GetOdometer() Odometer {
+	return elev.odometer
+}
+
+// The events not published yet, that are forgotten by the elevator
+func (elev *elevator) TakeEvents() []Event {
+	events := elev.events
+	elev.events = nil
+	return events
+}
+
+// Persons in the elevator, and their weight
+func (elev *elevator) GetLoad() (persons int, kilograms int) {
+	for i := range elev.assignedTrips {
+		if elev.assignedTrips[i].Event == Boarded {
+			persons++
+			kilograms += elev.assignedTrips[i].Weight
+		}
+	}
+	return persons, kilograms
+}
+
+func (elev *elevator) SetMovementPolicy(policy MovementPolicy) {
+	elev.policy = policy
+}
+
+// An elevator can't be emptier than one person
+func (elev *elevator) SetCapacity(persons int, ratedLoad int) {
+	elev.capacity = int(math.Max(float64(persons), 1))
+	elev.ratedLoad = int(math.Max(float64(ratedLoad), averageUserWeight))
+}
+
+// Tells if the elevator could still carry another user, once all the users assigned to it are in
+func (elev *elevator) HasRoomForAnotherTrip(weight int) bool {
+	persons, load := 0, 0
+	for i := range elev.assignedTrips {
+		if elev.assignedTrips[i].Call != CarButtonCall {
+			persons++
+			load += elev.assignedTrips[i].Weight
+		}
+	}
+	return persons < elev.capacity && load+weight <= elev.ratedLoad
+}
+
+/************** END OF ELEVATOR INTERFACE GETTERS AND SETTERS *************/
+
+/********* ELEVATOR INTERNAL HELPER FUNCTIONS, NOT OFFERED IN THE INTERFACE ***************/
+// An elevator travels one floor per time unit, so instead of jumping to the
+// chosen floor it gets one floor closer to it, without leaving the building
+func (elev *elevator) moveOneFloorTowards(floor int) {
+	if floor > elev.floorNumber && elev.floorNumber < elev.topFloor {
+		elev.floorNumber++
+	} else if floor < elev.floorNumber && elev.floorNumber > 0 {
+		elev.floorNumber--
+	}
+}
+
+// Removes a completed trip, leaving the rest of the trips in their order. The trips given are not changed
+func removeAssignedTrip(assignedTrips TripQueue, i int) TripQueue {
+	return append(append(TripQueue{}, assignedTrips[:i]...), assignedTrips[i+1:]...)
+}
+
+// Tells the direction the elevator has to take to go to the nearest floor where a user is waiting or wants to go
+func (elev *elevator) directionOfTheNearestTask() Direction {
+	nearestTask := 9999
+	direction := UP
+	for i := range elev.assignedTrips {
+		taskFloor := elev.assignedTrips[i].nextTaskFloor()
+		distance := int(math.Abs(float64(taskFloor - elev.floorNumber)))
+		if distance < nearestTask {
+			nearestTask = distance
+			if taskFloor < elev.floorNumber {
+				direction = DOWN
+			} else {
+				direction = UP
+			}
+		}
+	}
+	return direction
+}
+
+// The floor where the elevator has to go next for this trip: where the user is waiting, or where he wants to go
+func (trip TripDetails) nextTaskFloor() int {
+	if trip.Event.isWaiting() {
+		return trip.FromFloor
+	}
+	return trip.ToFloor
+}
+
+// Tells if there's some user waiting in this floor for this elevator, and there's room for him in it
+func (elev *elevator) someoneCanGetInInThisFloor() bool {
+	for i := range elev.assignedTrips {
+		trip := elev.assignedTrips[i]
+		if trip.FromFloor == elev.floorNumber && trip.Event.isWaiting() && elev.hasRoomFor(trip.Weight) {
+			return true
+		}
+	}
+	return false
+}
+
+// Tells if a user of this weight fits now in the elevator
+func (elev *elevator) hasRoomFor(weight int) bool {
+	persons, load := elev.GetLoad()
+	return persons < elev.capacity && load+weight <= elev.ratedLoad
+}
+
+/**
+ * 	It makes sure all the users that wanted to step-out in this floor are out, then move to the next floor
+ */
+func noMoreUsersToStepOutInThisFloor(assignedTrips TripQueue, floorNumber int) bool {
+	for i := range assignedTrips {
+		if assignedTrips[i].ToFloor == floorNumber && assignedTrips[i].Event.isOnBoard() {
+			return false
+		}
+	}
+	return true
+}
+
+/********* END OF ELEVATOR INTERNAL HELPER FUNCTIONS, NOT OFFERED IN THE INTERFACE ***************/
@@ -0,0 +1,471 @@
+package lift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Builds the elevator control system of a test, which must not be rejected
+func newSystem(t testing.TB, numberOfElevators int, numberOfFloors int, options ...Option) *elevatorControlSystem {
+	t.Helper()
+	control, err := NewElevatorControlSystem(numberOfElevators, numberOfFloors, options...)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return control.(*elevatorControlSystem)
+}
+
+func TestTickMovesTheElevatorsInLockstep(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.PickUpButtonWasPushed("User1", 0, 4)
+	control.Update(1, 5, "DOWN")
+	control.PickUpButtonWasPushed("User2", 5, 1)
+
+	// Both elevators spend three ticks opening, holding and closing the doors, then travel one floor per tick
+	control.Advance(5)
+
+	if control.Now() != 5 {
+		t.Errorf("expected the simulated time to be 5, got %d", control.Now())
+	}
+	if floor := control.Elevators[0].GetFloorNumber(); floor != 2 {
+		t.Errorf("expected elevator 0 in floor 2, got %d", floor)
+	}
+	if floor := control.Elevators[1].GetFloorNumber(); floor != 3 {
+		t.Errorf("expected elevator 1 in floor 3, got %d", floor)
+	}
+
+	control.Step()
+
+	steps := control.Elevators[0].GetStepList()
+	last := steps[len(steps)-1]
+	if last.Event != Alighted || last.Time != 8 {
+		t.Errorf("expected User1 to exit at t=8, got %v at t=%d", last.Event, last.Time)
+	}
+}
+
+func TestRunUntilIdleStopsWithTheContext(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 0, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := control.RunUntilIdle(ctx); !errors.Is(err, context.Canceled) || control.Now() != 0 {
+		t.Errorf("expected the elevators not to move once the context is done, got %v at t=%d", err, control.Now())
+	}
+
+	if err := control.RunUntilIdle(context.Background()); err != nil || control.Report().Completed != 1 {
+		t.Errorf("expected User1 to arrive, got %v and %+v", err, control.Report())
+	}
+	if now := control.Now(); control.RunUntilIdle(context.Background()) != nil || control.Now() != now {
+		t.Errorf("expected an idle system not to move")
+	}
+}
+
+func TestScheduledPickUpIsDispatchedWhereTheElevatorsAreAtThatTime(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.PickUpButtonWasPushed("User1", 0, 10)
+	control.SchedulePickUp(8, "User2", 9, 10)
+
+	control.Advance(8)
+	if trips := control.Elevators[0].GetAssignedTrips(); len(trips) != 1 {
+		t.Fatalf("expected User2 not to be dispatched before t=8, elevator 0 has %d trips", len(trips))
+	}
+
+	control.Tick()
+	trips := control.Elevators[0].GetAssignedTrips()
+	if len(trips) != 2 || trips[1].UserID != "User2" || trips[1].ElevatorFloor != 5 {
+		t.Fatalf("expected User2 to be dispatched to elevator 0 while it is in floor 5, got %+v", trips)
+	}
+
+	control.Step()
+	steps := control.Elevators[0].GetStepList()
+	last := steps[len(steps)-1]
+	if last.UserID != "User2" || last.Event != Alighted || last.ElevatorFloor != 10 {
+		t.Errorf("expected User2 to be dropped-off in floor 10, got %+v", last)
+	}
+}
+
+func TestElevatorStateMachine(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	elev := control.Elevators[0]
+
+	if err := control.Update(0, 3, "SIDEWAYS"); err == nil {
+		t.Errorf("expected the SIDEWAYS direction to be rejected")
+	}
+	if elev.GetState() != Idle || elev.GetDirection() != STOPPED {
+		t.Errorf("expected a new elevator to be IDLE and STOPPED, got %v and %v", elev.GetState(), elev.GetDirection())
+	}
+
+	control.PickUpButtonWasPushed("User1", 0, 1)
+	states := []ElevatorState{}
+	for len(elev.GetAssignedTrips()) > 0 {
+		control.Tick()
+		states = append(states, elev.GetState())
+	}
+	expected := []ElevatorState{DoorsOpening, DoorsOpen, DoorsClosing, MovingUp, DoorsOpening, DoorsOpen}
+	if fmt.Sprint(states) != fmt.Sprint(expected) {
+		t.Errorf("expected the elevator to go through %v, got %v", expected, states)
+	}
+
+	if err := control.Update(0, 5, "UP"); err == nil {
+		t.Errorf("expected the elevator not to be moved with the doors open")
+	}
+	control.Tick()
+	if err := control.Update(0, 5, string(OUT_OF_SERVICE)); err != nil {
+		t.Fatalf("expected the elevator to be taken out of service, got %v", err)
+	}
+
+	control.PickUpButtonWasPushed("User2", 5, 6)
+	if len(elev.GetAssignedTrips()) != 0 || len(control.Elevators[1].GetAssignedTrips()) != 1 {
+		t.Errorf("expected an elevator out of service not to be assigned any trip")
+	}
+}
+
+func TestInvalidRequestsAreRejected(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	pickUp := func(userID string, pickUpFloor int, dropOffFloor int) error {
+		_, err := control.PickUpButtonWasPushed(userID, pickUpFloor, dropOffFloor)
+		return err
+	}
+
+	checks := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"pick-up below the ground floor", pickUp("User1", -1, 5), ErrFloorOutOfRange},
+		{"drop-off above the top floor", pickUp("User2", 3, 11), ErrFloorOutOfRange},
+		{"trip to the same floor", pickUp("User3", 7, 7), ErrSameFloor},
+		{"scheduled trip to the same floor", control.SchedulePickUp(10, "User4", 2, 2), ErrSameFloor},
+		{"update of an unknown elevator", control.Update(2, 3, "UP"), ErrUnknownElevator},
+		{"update of a negative elevator", control.Update(-1, 3, "UP"), ErrUnknownElevator},
+		{"update to an unknown floor", control.Update(1, 11, "UP"), ErrFloorOutOfRange},
+		{"update to an unknown direction", control.Update(1, 3, "SIDEWAYS"), ErrInvalidDirection},
+		{"valid pick-up", pickUp("User5", 0, 10), nil},
+	}
+	for _, check := range checks {
+		if !errors.Is(check.err, check.want) {
+			t.Errorf("%v: expected error %v, got %v", check.name, check.want, check.err)
+		}
+	}
+}
+
+func TestStuckTripsAreFailedWithoutStoppingTheOtherElevators(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.PickUpButtonWasPushed("User1", 0, 5)
+	control.Update(1, 3, "UP")
+	control.PickUpButtonWasPushed("User2", 3, 4)
+	// A trip to a floor the building doesn't have, that the elevator will never reach
+	control.Elevators[0].AssignTrip(TripDetails{UserID: "Ghost", Event: Assigned, FromFloor: 12, ToFloor: 2, TripDirection: DOWN})
+
+	control.Step()
+
+	failures := control.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("expected only the trip to the unreachable floor to fail, got %v", failures)
+	}
+	var stuck *StuckTripError
+	if !errors.As(failures[0], &stuck) || !errors.Is(failures[0], ErrNoProgress) {
+		t.Fatalf("expected a StuckTripError, got %v", failures[0])
+	}
+	if stuck.ElevatorID != 0 || stuck.UserID != "Ghost" || stuck.FromFloor != 12 || stuck.ToFloor != 2 {
+		t.Errorf("expected the failure to name elevator 0, Ghost and floors 12 and 2, got %v", stuck)
+	}
+	for i, user := range []string{"User1", "User2"} {
+		steps := control.Elevators[i].GetStepList()
+		last := steps[len(steps)-1]
+		if last.UserID != user || last.Event != Alighted {
+			t.Errorf("expected %v to complete the trip, got %+v", user, last)
+		}
+	}
+}
+
+func TestFullElevatorsLeaveTheUsersWaiting(t *testing.T) {
+	control := newSystem(t, 2, 10, WithCapacity(2, 630), WithCarCapacity(1, 3, 150))
+	control.Update(1, 5, "STOPPED")
+	control.PickUpButtonWasPushed("User1", 0, 3)
+	control.PickUpButtonWasPushed("User2", 0, 4)
+	control.PickUpButtonWasPushed("User3", 0, 5)
+	control.PickUpButtonWasPushed("User4", 0, 6)
+	control.PickUpButtonWasPushed("User5", 1, 2)
+
+	if trips := control.Elevators[0].GetAssignedTrips(); len(trips) != 2 {
+		t.Errorf("expected elevator 0 to take only 2 users, got %d", len(trips))
+	}
+	if trips := control.Elevators[1].GetAssignedTrips(); len(trips) != 2 {
+		t.Errorf("expected elevator 1 to take only the 2 users weighing 150 kg, got %d", len(trips))
+	}
+	if len(control.scheduled) != 1 || control.scheduled[0].UserID != "User5" {
+		t.Fatalf("expected User5 to wait for an elevator with room, got %+v", control.scheduled)
+	}
+
+	control.Step()
+
+	if len(control.scheduled) != 0 || len(control.Failures()) != 0 {
+		t.Errorf("expected every user to complete the trip, waiting %+v, failures %v", control.scheduled, control.Failures())
+	}
+	for i := range control.Elevators {
+		for _, step := range control.Elevators[i].GetStepList() {
+			if step.UserID == "User5" && step.Event == Alighted {
+				return
+			}
+		}
+	}
+	t.Errorf("expected User5 to be dropped-off once an elevator had room")
+}
+
+func TestUsersKeepQueuedWhileAllTheElevatorsAreOutOfService(t *testing.T) {
+	for name, dispatcher := range map[string]Dispatcher{"proximity": ProximityDispatcher{}, "eta": ETADispatcher{}, "energy": EnergyDispatcher{}} {
+		control := newSystem(t, 2, 10, WithDispatcher(dispatcher))
+		control.Update(0, 0, string(OUT_OF_SERVICE))
+		control.Update(1, 0, string(OUT_OF_SERVICE))
+		assignment, err := control.PickUpButtonWasPushed("User1", 3, 6)
+		if err != nil || assignment.ElevatorID != NoElevator {
+			t.Fatalf("%v: expected User1 to wait for an elevator in service, got %+v, %v", name, assignment, err)
+		}
+
+		control.Step()
+		if len(control.scheduled) != 1 || len(control.Failures()) != 0 {
+			t.Errorf("%v: expected User1 to keep queued without failures, waiting %+v, failures %v", name, control.scheduled, control.Failures())
+		}
+
+		control.Update(1, 0, string(STOPPED))
+		control.Step()
+		if journeys, _ := control.Passenger("User1"); !journeys[0].Completed() || journeys[0].ElevatorID != 1 {
+			t.Errorf("%v: expected elevator 1 to take User1 once back in service, got %+v", name, journeys)
+		}
+	}
+}
+
+func TestHallCallIsAnsweredAndTheCarCallTakesTheUserToTheFloor(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.Update(1, 9, "STOPPED")
+
+	assignment, err := control.HallCall(3, UP)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if assignment.ElevatorID != 0 || assignment.ETA == UnknownETA {
+		t.Fatalf("expected elevator 0 to answer the hall call, got %+v", assignment)
+	}
+	for control.Elevators[0].GetFloorNumber() != 3 || control.Elevators[0].GetState() != DoorsOpen {
+		control.Tick()
+	}
+	if trips := control.Elevators[0].GetAssignedTrips(); len(trips) != 0 {
+		t.Fatalf("expected the hall call to be answered, got %+v", trips)
+	}
+
+	if err := control.CarCall(0, 7); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := control.CarCall(0, 7); err != nil || len(control.Elevators[0].GetAssignedTrips()) != 1 {
+		t.Fatalf("expected the second push of the same button to make no difference")
+	}
+	control.Step()
+
+	if control.Elevators[0].GetFloorNumber() != 7 || len(control.Failures()) != 0 {
+		t.Errorf("expected elevator 0 to stop in floor 7, it is in floor %d with failures %v",
+			control.Elevators[0].GetFloorNumber(), control.Failures())
+	}
+	served := false
+	for _, step := range control.Elevators[0].GetStepList() {
+		served = served || step.Event == CarCallServed && step.ElevatorFloor == 7
+	}
+	if !served {
+		t.Errorf("expected the car call to floor 7 to be served")
+	}
+}
+
+func TestTheSameHallAndCarCallsTwiceAreRegisteredAndServedTwice(t *testing.T) {
+	control := newSystem(t, 1, 10)
+
+	for call := 0; call < 2; call++ {
+		if _, err := control.HallCall(4, UP); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		control.Step()
+		if err := control.CarCall(0, 8); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		control.Step()
+		if err := control.CarCall(0, 0); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		control.Step()
+	}
+
+	steps := map[EventKind]int{}
+	tripIDs := map[int]bool{}
+	for _, step := range control.Elevators[0].GetStepList() {
+		steps[step.Event]++
+		tripIDs[step.TripID] = true
+	}
+	for _, event := range []EventKind{HallCallRegistered, HallCallAnswered} {
+		if steps[event] != 2 {
+			t.Errorf("expected 2 %v steps, got %d", event, steps[event])
+		}
+	}
+	for _, event := range []EventKind{CarCallRegistered, CarCallServed} {
+		if steps[event] != 4 {
+			t.Errorf("expected 4 %v steps, got %d", event, steps[event])
+		}
+	}
+	if len(tripIDs) != 6 {
+		t.Errorf("expected every call to have its own trip ID, got %v", tripIDs)
+	}
+}
+
+func TestRemovingATripLeavesTheGivenTripsUnchanged(t *testing.T) {
+	trips := TripQueue{{UserID: "User1"}, {UserID: "User2"}, {UserID: "User3"}}
+
+	left := removeAssignedTrip(trips, 1)
+	if len(left) != 2 || left[0].UserID != "User1" || left[1].UserID != "User3" {
+		t.Errorf("expected User1 and User3 to be left, got %+v", left)
+	}
+	if trips[0].UserID != "User1" || trips[1].UserID != "User2" || trips[2].UserID != "User3" {
+		t.Errorf("expected the given trips not to change, got %+v", trips)
+	}
+}
+
+func TestInvalidHallAndCarCallsAreRejected(t *testing.T) {
+	control := newSystem(t, 2, 10)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"hall call in a floor out of range", hallCallError(control, 11, UP), ErrFloorOutOfRange},
+		{"hall call going nowhere", hallCallError(control, 3, STOPPED), ErrInvalidDirection},
+		{"hall call going up from the top floor", hallCallError(control, 10, UP), ErrInvalidDirection},
+		{"hall call going down from the ground floor", hallCallError(control, 0, DOWN), ErrInvalidDirection},
+		{"car call in an unknown elevator", control.CarCall(2, 3), ErrUnknownElevator},
+		{"car call to a floor out of range", control.CarCall(0, -1), ErrFloorOutOfRange},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.name, tt.want, tt.err)
+		}
+	}
+}
+
+func hallCallError(control ElevatorControlSystem, floor int, direction Direction) error {
+	_, err := control.HallCall(floor, direction)
+	return err
+}
+
+func TestConcurrentCallersWhileTheSystemIsStepped(t *testing.T) {
+	const kiosks, pushesPerKiosk = 8, 25
+	control := newSystem(t, 4, 20)
+	stepping := make(chan struct{})
+	var simulator sync.WaitGroup
+	simulator.Add(1)
+	go func() {
+		defer simulator.Done()
+		for {
+			select {
+			case <-stepping:
+				return
+			default:
+				control.Tick()
+			}
+		}
+	}()
+
+	var callers sync.WaitGroup
+	for kiosk := 0; kiosk < kiosks; kiosk++ {
+		callers.Add(1)
+		go func(kiosk int) {
+			defer callers.Done()
+			for push := 0; push < pushesPerKiosk; push++ {
+				userID := fmt.Sprintf("Kiosk%d-User%d", kiosk, push)
+				if _, err := control.PickUpButtonWasPushed(userID, (kiosk+push)%20, (kiosk+push+7)%20); err != nil {
+					t.Errorf("%v: unexpected error %v", userID, err)
+				}
+				control.Snapshot()
+				control.Report()
+				control.Passenger(userID)
+			}
+		}(kiosk)
+	}
+	callers.Add(1)
+	go func() {
+		defer callers.Done()
+		for floor := 0; floor < 20; floor++ {
+			control.Update(3, floor, "UP")
+			control.CarCall(2, floor)
+			control.Failures()
+			control.Now()
+		}
+	}()
+	callers.Wait()
+	close(stepping)
+	simulator.Wait()
+
+	for i := 0; i < 10000 && control.Report().Completed+control.Report().Failed < kiosks*pushesPerKiosk; i++ {
+		control.Tick()
+	}
+	report := control.Report()
+	if report.Journeys != kiosks*pushesPerKiosk || report.Completed+report.Failed != report.Journeys {
+		t.Errorf("expected the %d journeys to be over, got %d journeys, %d completed and %d failed",
+			kiosks*pushesPerKiosk, report.Journeys, report.Completed, report.Failed)
+	}
+}
+
+func TestSubscribersCanCallTheSystemBack(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	returns := 0
+	control.Subscribe(func(event Event) {
+		if returns < 2 {
+			returns++
+			control.PickUpButtonWasPushed(fmt.Sprintf("User%d", returns+1), event.Floor, (event.Floor+3)%10)
+		}
+	}, Alighted)
+	control.PickUpButtonWasPushed("User1", 0, 5)
+	control.Advance(100)
+
+	report := control.Report()
+	if report.Journeys != 3 || report.Completed != 3 {
+		t.Errorf("expected the 3 journeys to be completed, got %d journeys and %d completed", report.Journeys, report.Completed)
+	}
+}
+
+func TestSubscribersCanStepTheSystemFromTheirCallbacks(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	times := []int{}
+	stepped := false
+	control.Subscribe(func(event Event) {
+		times = append(times, event.Time)
+		if event.Kind == Boarded && !stepped {
+			stepped = true
+			control.Tick()
+			control.Advance(2)
+			control.Step()
+		}
+	})
+	control.PickUpButtonWasPushed("User1", 3, 8)
+	control.PickUpButtonWasPushed("User2", 0, 6)
+
+	done := make(chan struct{})
+	go func() {
+		control.Step()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected stepping the system from a callback not to deadlock")
+	}
+
+	if report := control.Report(); report.Completed != 2 {
+		t.Errorf("expected the 2 journeys to be completed, got %d", report.Completed)
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i] < times[i-1] {
+			t.Fatalf("expected the events in the order of the ticks, got t=%d after t=%d", times[i], times[i-1])
+		}
+	}
+}
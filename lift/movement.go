@@ -0,0 +1,144 @@
+package lift
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               ELEVATOR MOVEMENT POLICIES                        ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Tells how an elevator chooses the next floor it moves to, once it has nothing to do in its current floor
+type MovementPolicy int
+
+const (
+	// Goes to the nearest task in GoToNextFloorInElevatorsTaskList, and only changes its direction at the ends of the building
+	FollowTaskList MovementPolicy = iota
+	// Keeps its direction while it has tasks ahead, and changes it as soon as there's nothing more to do in that direction
+	LOOK
+	// Goes from one end of the building to the other while it has tasks, stopping where it has something to do
+	SCAN
+)
+
+var movementPolicyNames = map[MovementPolicy]string{
+	FollowTaskList: "FOLLOW TASK LIST",
+	LOOK:           "LOOK",
+	SCAN:           "SCAN",
+}
+
+func (policy MovementPolicy) String() string {
+	if name, ok := movementPolicyNames[policy]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+/**
+ *	Sets the movement policy of every elevator of the system. By default the elevators use FollowTaskList
+	@ policy MovementPolicy
+*/
+func WithMovementPolicy(policy MovementPolicy) Option {
+	return func(control *elevatorControlSystem) {
+		for i := range control.Elevators {
+			control.Elevators[i].SetMovementPolicy(policy)
+		}
+	}
+}
+
+/**
+ *	Sets the movement policy of one of the elevators of the system. It is ignored if the elevator doesn't exist
+	@ elevatorID int
+	@ policy MovementPolicy
+*/
+func WithCarMovementPolicy(elevatorID int, policy MovementPolicy) Option {
+	return func(control *elevatorControlSystem) {
+		if elev, err := control.getElevator(elevatorID); err == nil {
+			elev.SetMovementPolicy(policy)
+		}
+	}
+}
+
+/**
+ *	FollowTaskList policy: moves one floor towards the floor chosen by GoToNextFloorInElevatorsTaskList, changing the
+	direction only when the elevator reaches the top floor or the ground floor of the building
+*/
+func (elev *elevator) followTheTaskList() {
+	if elev.direction == UP {
+		if elev.floorNumber == elev.topFloor {
+			// If the elevator reached the TOPFLOOR of the building then
+			// change downwards and move to next floor
+			elev.direction = DOWN
+			elev.floorNumber--
+		} else {
+			elev.moveOneFloorTowards(elev.GoToNextFloorInElevatorsTaskList())
+		}
+	} else { // If the elevator is moving down
+		if elev.floorNumber == 0 {
+			// If the elevator reached the ground floor of the building then
+			// change upwards and move to next floor
+			elev.direction = UP
+			elev.floorNumber++
+		} else {
+			elev.moveOneFloorTowards(elev.GoToNextFloorInElevatorsTaskList())
+		}
+	}
+}
+
+/**
+ *	LOOK policy: moves one floor in the current direction while there's some user waiting or wanting to go
+	further in that direction. Otherwise it changes the direction, or stays in the floor if there's nothing to do
+*/
+func (elev *elevator) lookAhead() {
+	if !elev.hasTasksTowards(elev.direction) {
+		if !elev.hasTasksTowards(elev.direction.opposite()) {
+			return
+		}
+		elev.direction = elev.direction.opposite()
+	}
+	elev.moveOneFloorTowards(elev.floorNumber + elev.direction.step())
+}
+
+/**
+ *	SCAN policy: moves one floor in the current direction until it reaches the end of the building, and then
+	changes the direction, no matter where its tasks are
+*/
+func (elev *elevator) scanTheBuilding() {
+	if elev.direction == UP && elev.floorNumber == elev.topFloor {
+		elev.direction = DOWN
+	} else if elev.direction == DOWN && elev.floorNumber == 0 {
+		elev.direction = UP
+	}
+	elev.moveOneFloorTowards(elev.floorNumber + elev.direction.step())
+}
+
+// Tells if there's a floor in this direction where a user is waiting for the elevator, or a user in the elevator wants to go
+func (elev *elevator) hasTasksTowards(direction Direction) bool {
+	for i := range elev.assignedTrips {
+		if (elev.assignedTrips[i].nextTaskFloor()-elev.floorNumber)*direction.step() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// The opposite direction, a stopped elevator has no opposite direction
+func (direction Direction) opposite() Direction {
+	switch direction {
+	case UP:
+		return DOWN
+	case DOWN:
+		return UP
+	}
+	return STOPPED
+}
+
+// How the floor number changes when the elevator moves one floor in this direction
+func (direction Direction) step() int {
+	switch direction {
+	case UP:
+		return 1
+	case DOWN:
+		return -1
+	}
+	return 0
+}
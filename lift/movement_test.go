@@ -0,0 +1,57 @@
+package lift
+
+import (
+	"testing"
+)
+
+// Runs the same trips with every movement policy, and tells the highest floor reached by the elevator and when it finished
+func runWithMovementPolicy(t *testing.T, policy MovementPolicy) (highestFloor int, finishedAt int) {
+	control := newSystem(t, 1, 10, WithCarMovementPolicy(0, policy))
+	control.PickUpButtonWasPushed("User1", 0, 3)
+	control.PickUpButtonWasPushed("User2", 1, 0)
+
+	for control.hasPendingTrips() {
+		control.Tick()
+		if floor := control.Elevators[0].GetFloorNumber(); floor > highestFloor {
+			highestFloor = floor
+		}
+	}
+	return highestFloor, control.Now()
+}
+
+func TestLOOKChangesDirectionWhenThereIsNothingAhead(t *testing.T) {
+	lookHighest, lookFinished := runWithMovementPolicy(t, LOOK)
+	if lookHighest != 3 {
+		t.Errorf("expected LOOK to turn back in floor 3, got up to floor %d", lookHighest)
+	}
+
+	for _, policy := range []MovementPolicy{FollowTaskList, SCAN} {
+		highest, finished := runWithMovementPolicy(t, policy)
+		if highest != 10 {
+			t.Errorf("expected %v to go up to the top floor, got up to floor %d", policy, highest)
+		}
+		if finished <= lookFinished {
+			t.Errorf("expected %v to finish after LOOK at t=%d, finished at t=%d", policy, lookFinished, finished)
+		}
+	}
+}
+
+func TestLOOKPicksUpTheUsersBelowWhileGoingDown(t *testing.T) {
+	control := newSystem(t, 1, 10, WithMovementPolicy(LOOK))
+	control.Update(0, 6, "DOWN")
+	control.PickUpButtonWasPushed("User1", 2, 0)
+	control.PickUpButtonWasPushed("User2", 8, 9)
+
+	control.Step()
+
+	steps := control.Elevators[0].GetStepList()
+	boarded := []string{}
+	for _, step := range steps {
+		if step.Event == Boarded {
+			boarded = append(boarded, step.UserID)
+		}
+	}
+	if len(boarded) != 2 || boarded[0] != "User1" {
+		t.Errorf("expected User1 to be picked-up first while the elevator goes down, got %v", boarded)
+	}
+}
@@ -0,0 +1,212 @@
+package lift
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               REAL TIME MODE                                    ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// Wall clock duration of a time unit when the system runs in real time, unless WithRealTimeFactor changes it
+const defaultTimeUnit = time.Second
+
+/**
+ *	A button pushed in a system running in real time. The Kind tells which fields are used:
+
+	- PassengerCall: a user in FromFloor wants to go to ToFloor, like PickUpButtonWasPushed
+	- HallButtonCall: somebody in FromFloor wants to go in Direction, like HallCall
+	- CarButtonCall: somebody in the elevator ElevatorID wants to go to ToFloor, like CarCall
+
+	If Result is not nil, it receives the assignment of the call, or the error if the call is rejected, so it
+	must have room for it
+*/
+type Call struct {
+	Kind       CallKind
+	UserID     string
+	FromFloor  int
+	ToFloor    int
+	Direction  Direction
+	ElevatorID int
+	Result     chan<- CallResult
+}
+
+// What happened to a Call. The assignment of a CarButtonCall is its elevator, with an UnknownETA
+type CallResult struct {
+	Assignment Assignment
+	Err        error
+}
+
+/**
+ *	Sets how much faster than the wall clock the system runs in real time. By default, a time unit lasts one
+	second, and a factor of 60 makes it last one sixtieth of a second. Factors not greater than 0 are ignored
+	@ factor float64
+*/
+func WithRealTimeFactor(factor float64) Option {
+	return func(control *elevatorControlSystem) {
+		if factor > 0 {
+			control.timeUnit = time.Duration(float64(defaultTimeUnit) / factor)
+		}
+	}
+}
+
+/**
+ *	Runs the system in real time until the context is done, and tells why it stopped. Every elevator is moved
+	by its own goroutine, and a clock moves all of them by one time unit at every tick of the wall clock, with the
+	same logic of Tick. The calls received meanwhile are answered with the same logic of PickUpButtonWasPushed,
+	HallCall and CarCall, and the ones received before a tick are dispatched before the elevators move.
+	The system can still be called from other goroutines while it runs, but Tick, Advance and Step fail with
+	ErrAlreadyRunning meanwhile, so the elevators are only moved by its clock. It can't run twice at the same time
+	@ ctx context.Context
+	@ calls <-chan Call
+*/
+func (control *elevatorControlSystem) Run(ctx context.Context, calls <-chan Call) error {
+	control.mutex.Lock()
+	if control.running {
+		control.mutex.Unlock()
+		return ErrAlreadyRunning
+	}
+	control.running = true
+	timeUnit := control.timeUnit
+	cars := control.startTheCars()
+	control.mutex.Unlock()
+
+	defer func() {
+		control.mutex.Lock()
+		control.running = false
+		control.mutex.Unlock()
+	}()
+	defer cars.stop()
+
+	clock := time.NewTicker(timeUnit)
+	defer clock.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case call, open := <-calls:
+			if !open {
+				calls = nil
+				continue
+			}
+			control.answer(ctx, call)
+		case <-clock.C:
+			calls = control.answerTheWaitingCalls(ctx, calls)
+			control.mutex.Lock()
+			control.publish(control.tickWith(cars.move))
+		}
+	}
+}
+
+/***** REAL TIME MODE HELPER FUNCTIONS *************/
+
+// The goroutines moving the elevators of a system running in real time
+type cars struct {
+	ticks   []chan int     // Time of every tick, sent to the goroutine of every elevator
+	events  chan carEvents // Events of the elevators once they have moved
+	stopped sync.WaitGroup // Waits for the goroutines to stop
+}
+
+// The events of an elevator after a tick
+type carEvents struct {
+	elevatorID int
+	events     []Event
+}
+
+/**
+ *	Starts a goroutine for every elevator of the system, which moves the elevator every time the clock ticks,
+	until the cars are stopped. The caller must hold the mutex of the system
+*/
+func (control *elevatorControlSystem) startTheCars() *cars {
+	cars := &cars{events: make(chan carEvents, len(control.Elevators))}
+	for i := range control.Elevators {
+		ticks := make(chan int)
+		cars.ticks = append(cars.ticks, ticks)
+		cars.stopped.Add(1)
+		go func(elevatorID int, elev Elevator) {
+			defer cars.stopped.Done()
+			for now := range ticks {
+				elev.Tick(now)
+				cars.events <- carEvents{elevatorID: elevatorID, events: elev.TakeEvents()}
+			}
+		}(i, control.Elevators[i])
+	}
+	return cars
+}
+
+// Stops the goroutines of the elevators, and waits for them to stop
+func (cars *cars) stop() {
+	for _, ticks := range cars.ticks {
+		close(ticks)
+	}
+	cars.stopped.Wait()
+}
+
+/**
+ *	Moves every elevator at the same time with its goroutine, and waits for all of them to tell their events,
+	which are sorted in the order of the elevators like in the simulation
+	@ now int
+*/
+func (cars *cars) move(now int) []Event {
+	for _, ticks := range cars.ticks {
+		ticks <- now
+	}
+	eventsOfTheCars := make([][]Event, len(cars.ticks))
+	for range cars.ticks {
+		moved := <-cars.events
+		eventsOfTheCars[moved.elevatorID] = moved.events
+	}
+
+	events := []Event{}
+	for _, eventsOfTheCar := range eventsOfTheCars {
+		events = append(events, eventsOfTheCar...)
+	}
+	return events
+}
+
+// Answers the calls already waiting in the channel, so they are dispatched before the elevators move
+func (control *elevatorControlSystem) answerTheWaitingCalls(ctx context.Context, calls <-chan Call) <-chan Call {
+	for waiting := len(calls); waiting > 0; waiting-- {
+		call, open := <-calls
+		if !open {
+			return nil
+		}
+		control.answer(ctx, call)
+	}
+	return calls
+}
+
+// Answers a call with the method of the system for its kind, and sends the result to the caller if it wants it
+func (control *elevatorControlSystem) answer(ctx context.Context, call Call) {
+	result := CallResult{}
+	switch call.Kind {
+	case PassengerCall:
+		result.Assignment, result.Err = control.PickUpButtonWasPushed(call.UserID, call.FromFloor, call.ToFloor)
+	case HallButtonCall:
+		result.Assignment, result.Err = control.HallCall(call.FromFloor, call.Direction)
+	case CarButtonCall:
+		result.Assignment = Assignment{ElevatorID: call.ElevatorID, ETA: UnknownETA}
+		if result.Err = control.CarCall(call.ElevatorID, call.ToFloor); result.Err != nil {
+			result.Assignment.ElevatorID = NoElevator
+		}
+	default:
+		result.Assignment = Assignment{ElevatorID: NoElevator, ETA: UnknownETA}
+		result.Err = fmt.Errorf("%w: %v", ErrUnknownCall, call.Kind)
+	}
+
+	if call.Result != nil {
+		select {
+		case call.Result <- result:
+		case <-ctx.Done():
+		}
+	}
+}
+
+/***** END OF REAL TIME MODE HELPER FUNCTIONS *************/
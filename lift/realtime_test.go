@@ -0,0 +1,94 @@
+package lift
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRealTimeModeMovesTheElevatorsLikeTheSimulation(t *testing.T) {
+	simulated := newSystem(t, 2, 10)
+	simulated.PickUpButtonWasPushed("User1", 0, 5)
+	simulated.PickUpButtonWasPushed("User2", 3, 1)
+	for simulated.Report().Completed < 2 {
+		simulated.Tick()
+	}
+
+	live := newSystem(t, 2, 10, WithRealTimeFactor(1000))
+	alighted := live.SubscribeChannel(2, Block, Alighted)
+	calls := make(chan Call, 2)
+	calls <- Call{Kind: PassengerCall, UserID: "User1", FromFloor: 0, ToFloor: 5}
+	calls <- Call{Kind: PassengerCall, UserID: "User2", FromFloor: 3, ToFloor: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error)
+	go func() { stopped <- live.Run(ctx, calls) }()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-alighted.C:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the users to alight in real time")
+		}
+	}
+	cancel()
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+
+	want, got := simulated.Snapshot(), live.Snapshot()
+	for i := range want.Elevators {
+		if !reflect.DeepEqual(want.Elevators[i].Steps, got.Elevators[i].Steps) {
+			t.Errorf("elevator %d: expected the steps of the simulation %+v, got %+v", i, want.Elevators[i].Steps, got.Elevators[i].Steps)
+		}
+	}
+}
+
+func TestRealTimeModeAnswersTheCalls(t *testing.T) {
+	control := newSystem(t, 2, 10, WithRealTimeFactor(1000))
+	calls := make(chan Call)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error)
+	go func() { stopped <- control.Run(ctx, calls) }()
+
+	var tests = []struct {
+		call     Call
+		answered bool
+		err      error
+	}{
+		{Call{Kind: PassengerCall, UserID: "User1", FromFloor: 2, ToFloor: 6}, true, nil},
+		{Call{Kind: PassengerCall, UserID: "User2", FromFloor: 4, ToFloor: 4}, false, ErrSameFloor},
+		{Call{Kind: HallButtonCall, FromFloor: 7, Direction: DOWN}, true, nil},
+		{Call{Kind: HallButtonCall, FromFloor: 0, Direction: DOWN}, false, ErrInvalidDirection},
+		{Call{Kind: CarButtonCall, ElevatorID: 1, ToFloor: 3}, true, nil},
+		{Call{Kind: CarButtonCall, ElevatorID: 2, ToFloor: 3}, false, ErrUnknownElevator},
+		{Call{Kind: CallKind(42)}, false, ErrUnknownCall},
+	}
+	for _, test := range tests {
+		results := make(chan CallResult, 1)
+		test.call.Result = results
+		calls <- test.call
+		result := <-results
+		if (result.Assignment.ElevatorID != NoElevator) != test.answered || !errors.Is(result.Err, test.err) {
+			t.Errorf("%+v: expected answered %v and error %v, got %+v", test.call, test.answered, test.err, result)
+		}
+	}
+
+	if err := control.Run(ctx, calls); !errors.Is(err, ErrAlreadyRunning) {
+		t.Errorf("expected a second Run to be rejected, got %v", err)
+	}
+	for name, step := range map[string]func() error{"Tick": control.Tick, "Step": control.Step, "Advance": func() error { return control.Advance(3) }} {
+		if err := step(); !errors.Is(err, ErrAlreadyRunning) {
+			t.Errorf("expected %v to be rejected while the system runs in real time, got %v", name, err)
+		}
+	}
+	cancel()
+	if err := <-stopped; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+	if err := control.Tick(); err != nil {
+		t.Errorf("expected Tick to move the elevators once Run stopped, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package lift
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               KEY PERFORMANCE INDICATORS                        ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+/**
+ *	How well the elevators did their job in a simulation run: how long the users waited and travelled, and how
+	much the elevators moved to take them
+*/
+type Report struct {
+	Duration    int           // Simulated time units of the run
+	Journeys    int           // Users who pushed the pick-up button
+	Completed   int           // Users who arrived to their drop-off floor
+	Failed      int           // Users whose trip was failed by the liveness guard
+	WaitTime    TimeStatistic // Since the users pushed the pick-up button until they got into the elevator
+	JourneyTime TimeStatistic // Since the users pushed the pick-up button until they stepped out of the elevator
+	Energy      float64       // Kilowatt-hours drawn by all the elevators
+	Cars        []CarReport
+}
+
+// Summary of the time units a group of users spent doing something
+type TimeStatistic struct {
+	Count  int
+	Mean   float64
+	Median float64
+	P95    int // 95th percentile, with the nearest rank method
+	Max    int
+}
+
+// What an elevator did in a simulation run
+type CarReport struct {
+	ElevatorID         int
+	Stops              int     // Times the elevator opened its doors
+	FloorsTravelled    int     // Floors the elevator went up or down
+	DirectionReversals int     // Times the elevator started moving in the opposite direction of its last move
+	BusyTime           int     // Time units the elevator had trips assigned or wasn't idle
+	Utilisation        float64 // Share of the run the elevator was busy, between 0 and 1
+	Energy             float64 // Kilowatt-hours drawn by the elevator, according to its EnergyModel
+}
+
+/**
+ *	Tells the key performance indicators of the simulation run so far, from the journeys of the users and the
+	odometer of every elevator
+*/
+func (control *elevatorControlSystem) Report() Report {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	report := Report{Duration: control.clock, Journeys: len(control.journeys), Cars: []CarReport{}}
+
+	waitTimes, journeyTimes := []int{}, []int{}
+	for _, journey := range control.journeys {
+		if journey.WaitTime() != NotYet {
+			waitTimes = append(waitTimes, journey.WaitTime())
+		}
+		if journey.Completed() {
+			report.Completed++
+			journeyTimes = append(journeyTimes, journey.JourneyTime())
+		}
+		if journey.FailedAt != NotYet {
+			report.Failed++
+		}
+	}
+	report.WaitTime = newTimeStatistic(waitTimes)
+	report.JourneyTime = newTimeStatistic(journeyTimes)
+
+	for i := range control.Elevators {
+		odometer := control.Elevators[i].GetOdometer()
+		car := CarReport{
+			ElevatorID:         i,
+			Stops:              odometer.Stops,
+			FloorsTravelled:    odometer.FloorsTravelled,
+			DirectionReversals: odometer.DirectionReversals,
+			BusyTime:           odometer.BusyTime,
+			Energy:             odometer.Energy / kilojoulesPerKWh,
+		}
+		if control.clock > 0 {
+			car.Utilisation = float64(odometer.BusyTime) / float64(control.clock)
+		}
+		report.Energy += car.Energy
+		report.Cars = append(report.Cars, car)
+	}
+	return report
+}
+
+/**
+ *	Prints the report as a table, so the reports of several runs can be compared
+	@ w io.Writer
+*/
+func (report Report) PrintTable(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(table, "Duration: %d time units. Journeys: %d, completed %d, failed %d. Energy: %.3f kWh.\n",
+		report.Duration, report.Journeys, report.Completed, report.Failed, report.Energy)
+	fmt.Fprintf(table, "\tUSERS\tMEAN\tMEDIAN\tP95\tMAX\t\n")
+	for _, row := range []struct {
+		name      string
+		statistic TimeStatistic
+	}{{"Wait time", report.WaitTime}, {"Journey time", report.JourneyTime}} {
+		fmt.Fprintf(table, "%v\t%d\t%.1f\t%.1f\t%d\t%d\t\n", row.name, row.statistic.Count,
+			row.statistic.Mean, row.statistic.Median, row.statistic.P95, row.statistic.Max)
+	}
+	fmt.Fprintln(table)
+	fmt.Fprintf(table, "Elevator\tSTOPS\tFLOORS\tREVERSALS\tBUSY\tUTILISATION\tENERGY (kWh)\t\n")
+	for _, car := range report.Cars {
+		fmt.Fprintf(table, "%d\t%d\t%d\t%d\t%d\t%.0f%%\t%.3f\t\n", car.ElevatorID, car.Stops, car.FloorsTravelled,
+			car.DirectionReversals, car.BusyTime, 100*car.Utilisation, car.Energy)
+	}
+	return table.Flush()
+}
+
+/***** REPORT HELPER FUNCTIONS *************/
+
+// Summarizes a list of time units. The statistic of an empty list is all zeros
+func newTimeStatistic(times []int) TimeStatistic {
+	statistic := TimeStatistic{Count: len(times)}
+	if len(times) == 0 {
+		return statistic
+	}
+
+	sorted := append([]int{}, times...)
+	sort.Ints(sorted)
+	total := 0
+	for _, time := range sorted {
+		total += time
+	}
+	statistic.Mean = float64(total) / float64(len(sorted))
+	middle := len(sorted) / 2
+	statistic.Median = float64(sorted[middle])
+	if len(sorted)%2 == 0 {
+		statistic.Median = float64(sorted[middle-1]+sorted[middle]) / 2
+	}
+	statistic.P95 = sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	statistic.Max = sorted[len(sorted)-1]
+	return statistic
+}
+
+// What an elevator has done since the system was started
+type Odometer struct {
+	Stops              int       // Times the elevator opened its doors
+	FloorsTravelled    int       // Floors the elevator went up or down
+	DirectionReversals int       // Times the elevator started moving in the opposite direction of its last move
+	BusyTime           int       // Time units the elevator had trips assigned or wasn't idle
+	Energy             float64   // Kilojoules drawn
+	lastMove           Direction // Direction of the last time the elevator changed of floor, "" if it never moved
+}
+
+// Takes note of what an elevator did in a tick
+func (odo *Odometer) takeNoteOfTheTick(floorBefore int, floorAfter int, stateBefore ElevatorState, stateAfter ElevatorState, busy bool) {
+	if busy {
+		odo.BusyTime++
+	}
+	if stateAfter == DoorsOpening && stateBefore != DoorsOpening {
+		odo.Stops++
+	}
+	if floorAfter == floorBefore {
+		return
+	}
+
+	move := UP
+	if floorAfter < floorBefore {
+		move = DOWN
+	}
+	if odo.lastMove != "" && odo.lastMove != move {
+		odo.DirectionReversals++
+	}
+	odo.lastMove = move
+	odo.FloorsTravelled += int(math.Abs(float64(floorAfter - floorBefore)))
+}
+
+/***** END OF REPORT HELPER FUNCTIONS *************/
@@ -0,0 +1,54 @@
+package lift
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReportTellsWhatTheUsersAndTheElevatorsDid(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.Update(0, 5, "STOPPED")
+	control.Update(1, 5, string(OUT_OF_SERVICE))
+	control.PickUpButtonWasPushed("User7", 0, 3)
+	control.Step()
+
+	report := control.Report()
+	if report.Journeys != 1 || report.Completed != 1 || report.Failed != 0 {
+		t.Errorf("expected one completed journey, got %+v", report)
+	}
+	if report.WaitTime.Max != 6 || report.JourneyTime.Max != 12 {
+		t.Errorf("expected User7 to wait 6 time units in a journey of 12, got %+v and %+v", report.WaitTime, report.JourneyTime)
+	}
+	// Goes down 5 floors to pick-up User7, and then up 3 floors to drop him off
+	want := CarReport{ElevatorID: 0, Stops: 2, FloorsTravelled: 8, DirectionReversals: 1, BusyTime: 13, Utilisation: 1,
+		Energy: report.Cars[0].Energy}
+	if report.Cars[0] != want || report.Cars[0].Energy <= 0 || report.Energy != report.Cars[0].Energy {
+		t.Errorf("expected elevator 0 to report %+v, got %+v", want, report.Cars[0])
+	}
+	if report.Cars[1] != (CarReport{ElevatorID: 1}) {
+		t.Errorf("expected elevator 1, out of service, to do nothing, got %+v", report.Cars[1])
+	}
+
+	table := strings.Builder{}
+	if err := report.PrintTable(&table); err != nil || !strings.Contains(table.String(), "Wait time") {
+		t.Errorf("expected the table to show the wait time, got %q, %v", table.String(), err)
+	}
+}
+
+func TestTimeStatistic(t *testing.T) {
+	tests := []struct {
+		times []int
+		want  TimeStatistic
+	}{
+		{[]int{}, TimeStatistic{}},
+		{[]int{4}, TimeStatistic{Count: 1, Mean: 4, Median: 4, P95: 4, Max: 4}},
+		{[]int{9, 1, 3, 7}, TimeStatistic{Count: 4, Mean: 5, Median: 5, P95: 9, Max: 9}},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 100},
+			TimeStatistic{Count: 20, Mean: 14.5, Median: 10.5, P95: 19, Max: 100}},
+	}
+	for _, tt := range tests {
+		if got := newTimeStatistic(tt.times); got != tt.want {
+			t.Errorf("%v: expected %+v, got %+v", tt.times, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package lift
+
+import (
+	"fmt"
+	"io"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               STATUS SNAPSHOT AND RENDERERS                     ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// State of the whole elevator control system at a moment of the simulation
+type SystemStatus struct {
+	Time      int             // Simulated time of the snapshot
+	TopFloor  int             // Top floor of the building
+	Elevators []CarStatus     // In the order of their IDs
+	Waiting   []PickUpRequest // Pick-up requests waiting to be assigned to an elevator, sorted by time
+}
+
+// State of an elevator at a moment of the simulation
+type CarStatus struct {
+	ElevatorID int
+	Floor      int
+	Direction  Direction
+	State      ElevatorState
+	Persons    int           // Persons in the elevator
+	Load       int           // Kilograms of the persons in the elevator
+	Trips      []TripDetails // Trips assigned to the elevator and not completed yet
+	Steps      []TripDetails // Steps performed by the elevator since the system was started
+	Failures   []error       // Trips the elevator was not able to complete
+}
+
+// Tells if any elevator has trips to complete, or any pick-up request is waiting for an elevator
+func (status SystemStatus) HasPendingTrips() bool {
+	if len(status.Waiting) > 0 {
+		return true
+	}
+	for _, car := range status.Elevators {
+		if len(car.Trips) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ *	Tells the state of every elevator of the system: where it is, what it is doing, who it is carrying, and which
+	trips it has been assigned. The snapshot is a copy, so it doesn't change when the simulation goes on
+*/
+func (control *elevatorControlSystem) Snapshot() SystemStatus {
+	control.mutex.Lock()
+	defer control.mutex.Unlock()
+	status := SystemStatus{
+		Time:      control.clock,
+		TopFloor:  control.TOPFLOOR,
+		Elevators: []CarStatus{},
+		Waiting:   append([]PickUpRequest{}, control.scheduled...),
+	}
+	for i := range control.Elevators {
+		status.Elevators = append(status.Elevators, control.carStatus(i))
+	}
+	return status
+}
+
+// Writes a snapshot of the elevator control system in some format
+type Renderer interface {
+	Render(w io.Writer, status SystemStatus) error
+}
+
+// Renders the state of every elevator in plain English, like Status()
+type StatusTextRenderer struct{}
+
+func (StatusTextRenderer) Render(w io.Writer, status SystemStatus) error {
+	out := &textWriter{w: w}
+	out.printf("\nCURRENT STATUS OF THIS ELEVATOR CONTROL SYSTEM: IS MANAGING %d PLANTS AND %d ELEVATORS\n"+
+		"======================================================================================\n",
+		status.TopFloor, len(status.Elevators))
+	for _, car := range status.Elevators {
+		out.printf("\n* Elevator %d is in floor %d, %v, carrying %d persons (%d kg)", car.ElevatorID, car.Floor, car.State, car.Persons, car.Load)
+		if len(car.Trips) > 0 {
+			out.printf(", %v, and it has been assigned %d tasks:\n\n", car.Direction.describe(), len(car.Trips))
+			for _, trip := range car.Trips {
+				out.printf(" - %v.\n", describeTask(trip))
+			}
+		} else {
+			out.printf(".")
+		}
+	}
+	return out.err
+}
+
+// Renders the steps performed by every elevator in plain English, like Step()
+type StepListTextRenderer struct{}
+
+func (StepListTextRenderer) Render(w io.Writer, status SystemStatus) error {
+	out := &textWriter{w: w}
+	out.printf("\n\nSTEP LIST OF OUR SYSTEM OF %d FLOORS AND %d ELEVATORS\n"+
+		"=====================================================\n", status.TopFloor, len(status.Elevators))
+	for _, car := range status.Elevators {
+		out.printf("\nElevator %d Performed This Step List\n----------------------------------------\n", car.ElevatorID)
+		for _, step := range car.Steps {
+			out.printf("[t=%d] Floor %d, %v. %v.\n", step.Time, step.ElevatorFloor, step.ElevatorDirection.describe(), describeStep(step))
+		}
+		for _, failure := range car.Failures {
+			out.printf("FAILED: %v.\n", failure)
+		}
+	}
+	return out.err
+}
+
+/***** STATUS HELPER FUNCTIONS *************/
+
+// State of an elevator of the system. The getters of the elevator return copies of its trips, steps and failures,
+// so the snapshot doesn't change when the simulation goes on
+func (control *elevatorControlSystem) carStatus(elevatorID int) CarStatus {
+	elev := control.Elevators[elevatorID]
+	persons, load := elev.GetLoad()
+	return CarStatus{
+		ElevatorID: elevatorID,
+		Floor:      elev.GetFloorNumber(),
+		Direction:  elev.GetDirection(),
+		State:      elev.GetState(),
+		Persons:    persons,
+		Load:       load,
+		Trips:      elev.GetAssignedTrips(),
+		Steps:      elev.GetStepList(),
+		Failures:   elev.GetFailures(),
+	}
+}
+
+// Writer remembering the first error, so the renderers only check it once at the end
+type textWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (out *textWriter) printf(format string, args ...interface{}) {
+	if out.err == nil {
+		_, out.err = fmt.Fprintf(out.w, format, args...)
+	}
+}
+
+/***** END OF STATUS HELPER FUNCTIONS *************/
@@ -0,0 +1,83 @@
+package lift
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotTellsTheStateOfEveryElevator(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.Update(1, 5, string(OUT_OF_SERVICE))
+	control.PickUpButtonWasPushed("User1", 0, 3)
+	control.PickUpButtonWasPushed("User2", 0, 6)
+	control.Advance(2)
+
+	status := control.Snapshot()
+	if status.Time != 2 || status.TopFloor != 10 || len(status.Elevators) != 2 {
+		t.Fatalf("expected the snapshot of 2 elevators in 10 floors at t=2, got %+v", status)
+	}
+	car := status.Elevators[0]
+	if car.Floor != 0 || car.State != DoorsOpen || car.Persons != 2 || car.Load != 2*averageUserWeight {
+		t.Errorf("expected elevator 0 to have its doors open in floor 0 with 2 persons, got %+v", car)
+	}
+	if len(car.Trips) != 2 || car.Trips[1].UserID != "User2" || car.Trips[1].FromFloor != 0 || car.Trips[1].ToFloor != 6 ||
+		car.Trips[1].Event != Boarded {
+		t.Errorf("expected elevator 0 to carry User1 and User2, got %+v", car.Trips)
+	}
+	if status.Elevators[1].State != OutOfService || len(status.Elevators[1].Trips) != 0 {
+		t.Errorf("expected elevator 1 to be out of service, got %+v", status.Elevators[1])
+	}
+
+	// The snapshot doesn't change when the simulation goes on
+	control.Step()
+	if len(status.Elevators[0].Trips) != 2 || status.Elevators[0].Floor != 0 {
+		t.Errorf("expected the snapshot to be a copy, got %+v", status.Elevators[0])
+	}
+}
+
+func TestGetElevatorAndTheGettersReturnCopies(t *testing.T) {
+	control := newSystem(t, 2, 10)
+	control.PickUpButtonWasPushed("User1", 0, 3)
+	control.PickUpButtonWasPushed("User2", 0, 6)
+	control.Advance(2)
+
+	car, err := control.GetElevator(0)
+	if err != nil || car.ElevatorID != 0 || len(car.Trips) != 2 || len(car.Steps) == 0 {
+		t.Fatalf("expected the state of elevator 0 with 2 trips, got %+v and %v", car, err)
+	}
+	car.Trips[0].ToFloor = 9
+	car.Steps[0].UserID = "Nobody"
+	control.Elevators[0].GetAssignedTrips()[1].ToFloor = 9
+	control.Elevators[0].GetStepList()[1].UserID = "Nobody"
+	trips, steps := control.Elevators[0].GetAssignedTrips(), control.Elevators[0].GetStepList()
+	if trips[0].ToFloor != 3 || trips[1].ToFloor != 6 || steps[0].UserID == "Nobody" || steps[1].UserID == "Nobody" {
+		t.Errorf("expected the elevator not to change, got the trips %+v and the steps %+v", trips, steps)
+	}
+
+	if _, err := control.GetElevator(2); !errors.Is(err, ErrUnknownElevator) {
+		t.Errorf("expected ErrUnknownElevator, got %v", err)
+	}
+}
+
+func TestTextRenderersWriteToAnyWriter(t *testing.T) {
+	control := newSystem(t, 1, 10)
+	control.PickUpButtonWasPushed("User1", 2, 4)
+
+	status := strings.Builder{}
+	if err := (StatusTextRenderer{}).Render(&status, control.Snapshot()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !strings.Contains(status.String(), " - User1 is in floor 2 This elevator will take him there. Wants to go to floor 4.") {
+		t.Errorf("expected the status to tell the task of User1, got %q", status.String())
+	}
+
+	control.Advance(10)
+	steps := strings.Builder{}
+	if err := (StepListTextRenderer{}).Render(&steps, control.Snapshot()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !strings.Contains(steps.String(), "Floor 4, going UP. User1 is exiting from the elevator in floor 4.") {
+		t.Errorf("expected the step list to tell User1 stepped out in floor 4, got %q", steps.String())
+	}
+}
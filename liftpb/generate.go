@@ -0,0 +1,5 @@
+// Package liftpb is the gRPC contract of the elevator control system, generated from lift.proto with buf,
+// protoc-gen-go and protoc-gen-go-grpc. The server backed by the lift package is in the grpcapi package.
+package liftpb
+
+//go:generate buf generate
@@ -0,0 +1,891 @@
+// gRPC contract of the elevator control system, mirroring the ElevatorControlSystem interface of the lift package.
+// The directions, states, calls and kinds of events have the same names of the JSON Lines export and the REST API.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: lift.proto
+
+package liftpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PickUpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PickUpFloor   int32                  `protobuf:"varint,2,opt,name=pick_up_floor,json=pickUpFloor,proto3" json:"pick_up_floor,omitempty"`
+	DropOffFloor  int32                  `protobuf:"varint,3,opt,name=drop_off_floor,json=dropOffFloor,proto3" json:"drop_off_floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickUpRequest) Reset() {
+	*x = PickUpRequest{}
+	mi := &file_lift_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickUpRequest) ProtoMessage() {}
+
+func (x *PickUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickUpRequest.ProtoReflect.Descriptor instead.
+func (*PickUpRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PickUpRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PickUpRequest) GetPickUpFloor() int32 {
+	if x != nil {
+		return x.PickUpFloor
+	}
+	return 0
+}
+
+func (x *PickUpRequest) GetDropOffFloor() int32 {
+	if x != nil {
+		return x.DropOffFloor
+	}
+	return 0
+}
+
+// The elevator that will take the user, and when, or -1 if the user has to wait for an elevator with room for him
+type PickUpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ElevatorId    int32                  `protobuf:"varint,1,opt,name=elevator_id,json=elevatorId,proto3" json:"elevator_id,omitempty"`
+	Eta           int32                  `protobuf:"varint,2,opt,name=eta,proto3" json:"eta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickUpResponse) Reset() {
+	*x = PickUpResponse{}
+	mi := &file_lift_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickUpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickUpResponse) ProtoMessage() {}
+
+func (x *PickUpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickUpResponse.ProtoReflect.Descriptor instead.
+func (*PickUpResponse) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PickUpResponse) GetElevatorId() int32 {
+	if x != nil {
+		return x.ElevatorId
+	}
+	return 0
+}
+
+func (x *PickUpResponse) GetEta() int32 {
+	if x != nil {
+		return x.Eta
+	}
+	return 0
+}
+
+type UpdateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ElevatorId int32                  `protobuf:"varint,1,opt,name=elevator_id,json=elevatorId,proto3" json:"elevator_id,omitempty"`
+	Floor      int32                  `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	// UP, DOWN, STOPPED or OUT_OF_SERVICE
+	Direction     string `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	mi := &file_lift_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdateRequest) GetElevatorId() int32 {
+	if x != nil {
+		return x.ElevatorId
+	}
+	return 0
+}
+
+func (x *UpdateRequest) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *UpdateRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type StepRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time units to advance, up to 10000, or until no elevator has pending trips if it's 0
+	Ticks         int32 `protobuf:"varint,1,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	mi := &file_lift_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StepRequest) GetTicks() int32 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+	mi := &file_lift_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{4}
+}
+
+type SystemStatus struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Time      int32                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	TopFloor  int32                  `protobuf:"varint,2,opt,name=top_floor,json=topFloor,proto3" json:"top_floor,omitempty"`
+	Elevators []*CarStatus           `protobuf:"bytes,3,rep,name=elevators,proto3" json:"elevators,omitempty"`
+	// Pick-up requests waiting to be assigned to an elevator
+	Waiting       []*WaitingRequest `protobuf:"bytes,4,rep,name=waiting,proto3" json:"waiting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemStatus) Reset() {
+	*x = SystemStatus{}
+	mi := &file_lift_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatus) ProtoMessage() {}
+
+func (x *SystemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
+func (*SystemStatus) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SystemStatus) GetTime() int32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetTopFloor() int32 {
+	if x != nil {
+		return x.TopFloor
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetElevators() []*CarStatus {
+	if x != nil {
+		return x.Elevators
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetWaiting() []*WaitingRequest {
+	if x != nil {
+		return x.Waiting
+	}
+	return nil
+}
+
+type CarStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ElevatorId    int32                  `protobuf:"varint,1,opt,name=elevator_id,json=elevatorId,proto3" json:"elevator_id,omitempty"`
+	Floor         int32                  `protobuf:"varint,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	Direction     string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Persons       int32                  `protobuf:"varint,5,opt,name=persons,proto3" json:"persons,omitempty"`
+	Load          int32                  `protobuf:"varint,6,opt,name=load,proto3" json:"load,omitempty"`
+	Trips         []*Trip                `protobuf:"bytes,7,rep,name=trips,proto3" json:"trips,omitempty"`
+	Failures      []string               `protobuf:"bytes,8,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CarStatus) Reset() {
+	*x = CarStatus{}
+	mi := &file_lift_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CarStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CarStatus) ProtoMessage() {}
+
+func (x *CarStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CarStatus.ProtoReflect.Descriptor instead.
+func (*CarStatus) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CarStatus) GetElevatorId() int32 {
+	if x != nil {
+		return x.ElevatorId
+	}
+	return 0
+}
+
+func (x *CarStatus) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *CarStatus) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *CarStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *CarStatus) GetPersons() int32 {
+	if x != nil {
+		return x.Persons
+	}
+	return 0
+}
+
+func (x *CarStatus) GetLoad() int32 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+func (x *CarStatus) GetTrips() []*Trip {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *CarStatus) GetFailures() []string {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// A trip assigned to an elevator, and the last event of the trip
+type Trip struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Call          string                 `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	FromFloor     int32                  `protobuf:"varint,4,opt,name=from_floor,json=fromFloor,proto3" json:"from_floor,omitempty"`
+	ToFloor       int32                  `protobuf:"varint,5,opt,name=to_floor,json=toFloor,proto3" json:"to_floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trip) Reset() {
+	*x = Trip{}
+	mi := &file_lift_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trip) ProtoMessage() {}
+
+func (x *Trip) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trip.ProtoReflect.Descriptor instead.
+func (*Trip) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Trip) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Trip) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *Trip) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Trip) GetFromFloor() int32 {
+	if x != nil {
+		return x.FromFloor
+	}
+	return 0
+}
+
+func (x *Trip) GetToFloor() int32 {
+	if x != nil {
+		return x.ToFloor
+	}
+	return 0
+}
+
+type WaitingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            int32                  `protobuf:"varint,1,opt,name=at,proto3" json:"at,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PickUpFloor   int32                  `protobuf:"varint,3,opt,name=pick_up_floor,json=pickUpFloor,proto3" json:"pick_up_floor,omitempty"`
+	DropOffFloor  int32                  `protobuf:"varint,4,opt,name=drop_off_floor,json=dropOffFloor,proto3" json:"drop_off_floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitingRequest) Reset() {
+	*x = WaitingRequest{}
+	mi := &file_lift_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitingRequest) ProtoMessage() {}
+
+func (x *WaitingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitingRequest.ProtoReflect.Descriptor instead.
+func (*WaitingRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WaitingRequest) GetAt() int32 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *WaitingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WaitingRequest) GetPickUpFloor() int32 {
+	if x != nil {
+		return x.PickUpFloor
+	}
+	return 0
+}
+
+func (x *WaitingRequest) GetDropOffFloor() int32 {
+	if x != nil {
+		return x.DropOffFloor
+	}
+	return 0
+}
+
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kinds of events to watch, like CAR_ARRIVED or BOARDED, or all of them if it's empty
+	Kinds         []string `protobuf:"bytes,1,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_lift_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchEventsRequest) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+type Event struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Kind       string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Time       int32                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	ElevatorId int32                  `protobuf:"varint,3,opt,name=elevator_id,json=elevatorId,proto3" json:"elevator_id,omitempty"`
+	Floor      int32                  `protobuf:"varint,4,opt,name=floor,proto3" json:"floor,omitempty"`
+	Direction  string                 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	// User of the trip, empty for the events of the elevator
+	UserId string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Call of the trip, empty for the events of the elevator
+	Call string `protobuf:"bytes,7,opt,name=call,proto3" json:"call,omitempty"`
+	// Floors of the trip, -1 when they are unknown
+	FromFloor     int32 `protobuf:"varint,8,opt,name=from_floor,json=fromFloor,proto3" json:"from_floor,omitempty"`
+	ToFloor       int32 `protobuf:"varint,9,opt,name=to_floor,json=toFloor,proto3" json:"to_floor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_lift_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_lift_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_lift_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Event) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Event) GetTime() int32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Event) GetElevatorId() int32 {
+	if x != nil {
+		return x.ElevatorId
+	}
+	return 0
+}
+
+func (x *Event) GetFloor() int32 {
+	if x != nil {
+		return x.Floor
+	}
+	return 0
+}
+
+func (x *Event) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *Event) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Event) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *Event) GetFromFloor() int32 {
+	if x != nil {
+		return x.FromFloor
+	}
+	return 0
+}
+
+func (x *Event) GetToFloor() int32 {
+	if x != nil {
+		return x.ToFloor
+	}
+	return 0
+}
+
+var File_lift_proto protoreflect.FileDescriptor
+
+const file_lift_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"lift.proto\x12\alift.v1\"r\n" +
+	"\rPickUpRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\"\n" +
+	"\rpick_up_floor\x18\x02 \x01(\x05R\vpickUpFloor\x12$\n" +
+	"\x0edrop_off_floor\x18\x03 \x01(\x05R\fdropOffFloor\"C\n" +
+	"\x0ePickUpResponse\x12\x1f\n" +
+	"\velevator_id\x18\x01 \x01(\x05R\n" +
+	"elevatorId\x12\x10\n" +
+	"\x03eta\x18\x02 \x01(\x05R\x03eta\"d\n" +
+	"\rUpdateRequest\x12\x1f\n" +
+	"\velevator_id\x18\x01 \x01(\x05R\n" +
+	"elevatorId\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\"#\n" +
+	"\vStepRequest\x12\x14\n" +
+	"\x05ticks\x18\x01 \x01(\x05R\x05ticks\"\x0f\n" +
+	"\rStatusRequest\"\xa4\x01\n" +
+	"\fSystemStatus\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x05R\x04time\x12\x1b\n" +
+	"\ttop_floor\x18\x02 \x01(\x05R\btopFloor\x120\n" +
+	"\televators\x18\x03 \x03(\v2\x12.lift.v1.CarStatusR\televators\x121\n" +
+	"\awaiting\x18\x04 \x03(\v2\x17.lift.v1.WaitingRequestR\awaiting\"\xe5\x01\n" +
+	"\tCarStatus\x12\x1f\n" +
+	"\velevator_id\x18\x01 \x01(\x05R\n" +
+	"elevatorId\x12\x14\n" +
+	"\x05floor\x18\x02 \x01(\x05R\x05floor\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\x12\x18\n" +
+	"\apersons\x18\x05 \x01(\x05R\apersons\x12\x12\n" +
+	"\x04load\x18\x06 \x01(\x05R\x04load\x12#\n" +
+	"\x05trips\x18\a \x03(\v2\r.lift.v1.TripR\x05trips\x12\x1a\n" +
+	"\bfailures\x18\b \x03(\tR\bfailures\"\x85\x01\n" +
+	"\x04Trip\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04call\x18\x02 \x01(\tR\x04call\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"from_floor\x18\x04 \x01(\x05R\tfromFloor\x12\x19\n" +
+	"\bto_floor\x18\x05 \x01(\x05R\atoFloor\"\x83\x01\n" +
+	"\x0eWaitingRequest\x12\x0e\n" +
+	"\x02at\x18\x01 \x01(\x05R\x02at\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\"\n" +
+	"\rpick_up_floor\x18\x03 \x01(\x05R\vpickUpFloor\x12$\n" +
+	"\x0edrop_off_floor\x18\x04 \x01(\x05R\fdropOffFloor\"*\n" +
+	"\x12WatchEventsRequest\x12\x14\n" +
+	"\x05kinds\x18\x01 \x03(\tR\x05kinds\"\xeb\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x05R\x04time\x12\x1f\n" +
+	"\velevator_id\x18\x03 \x01(\x05R\n" +
+	"elevatorId\x12\x14\n" +
+	"\x05floor\x18\x04 \x01(\x05R\x05floor\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04call\x18\a \x01(\tR\x04call\x12\x1d\n" +
+	"\n" +
+	"from_floor\x18\b \x01(\x05R\tfromFloor\x12\x19\n" +
+	"\bto_floor\x18\t \x01(\x05R\atoFloor2\xb4\x02\n" +
+	"\x15ElevatorControlSystem\x129\n" +
+	"\x06PickUp\x12\x16.lift.v1.PickUpRequest\x1a\x17.lift.v1.PickUpResponse\x124\n" +
+	"\x06Update\x12\x16.lift.v1.UpdateRequest\x1a\x12.lift.v1.CarStatus\x123\n" +
+	"\x04Step\x12\x14.lift.v1.StepRequest\x1a\x15.lift.v1.SystemStatus\x127\n" +
+	"\x06Status\x12\x16.lift.v1.StatusRequest\x1a\x15.lift.v1.SystemStatus\x12<\n" +
+	"\vWatchEvents\x12\x1b.lift.v1.WatchEventsRequest\x1a\x0e.lift.v1.Event0\x01B2Z0github.com/ArturoTarinVillaescusa/lift-go/liftpbb\x06proto3"
+
+var (
+	file_lift_proto_rawDescOnce sync.Once
+	file_lift_proto_rawDescData []byte
+)
+
+func file_lift_proto_rawDescGZIP() []byte {
+	file_lift_proto_rawDescOnce.Do(func() {
+		file_lift_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lift_proto_rawDesc), len(file_lift_proto_rawDesc)))
+	})
+	return file_lift_proto_rawDescData
+}
+
+var file_lift_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_lift_proto_goTypes = []any{
+	(*PickUpRequest)(nil),      // 0: lift.v1.PickUpRequest
+	(*PickUpResponse)(nil),     // 1: lift.v1.PickUpResponse
+	(*UpdateRequest)(nil),      // 2: lift.v1.UpdateRequest
+	(*StepRequest)(nil),        // 3: lift.v1.StepRequest
+	(*StatusRequest)(nil),      // 4: lift.v1.StatusRequest
+	(*SystemStatus)(nil),       // 5: lift.v1.SystemStatus
+	(*CarStatus)(nil),          // 6: lift.v1.CarStatus
+	(*Trip)(nil),               // 7: lift.v1.Trip
+	(*WaitingRequest)(nil),     // 8: lift.v1.WaitingRequest
+	(*WatchEventsRequest)(nil), // 9: lift.v1.WatchEventsRequest
+	(*Event)(nil),              // 10: lift.v1.Event
+}
+var file_lift_proto_depIdxs = []int32{
+	6,  // 0: lift.v1.SystemStatus.elevators:type_name -> lift.v1.CarStatus
+	8,  // 1: lift.v1.SystemStatus.waiting:type_name -> lift.v1.WaitingRequest
+	7,  // 2: lift.v1.CarStatus.trips:type_name -> lift.v1.Trip
+	0,  // 3: lift.v1.ElevatorControlSystem.PickUp:input_type -> lift.v1.PickUpRequest
+	2,  // 4: lift.v1.ElevatorControlSystem.Update:input_type -> lift.v1.UpdateRequest
+	3,  // 5: lift.v1.ElevatorControlSystem.Step:input_type -> lift.v1.StepRequest
+	4,  // 6: lift.v1.ElevatorControlSystem.Status:input_type -> lift.v1.StatusRequest
+	9,  // 7: lift.v1.ElevatorControlSystem.WatchEvents:input_type -> lift.v1.WatchEventsRequest
+	1,  // 8: lift.v1.ElevatorControlSystem.PickUp:output_type -> lift.v1.PickUpResponse
+	6,  // 9: lift.v1.ElevatorControlSystem.Update:output_type -> lift.v1.CarStatus
+	5,  // 10: lift.v1.ElevatorControlSystem.Step:output_type -> lift.v1.SystemStatus
+	5,  // 11: lift.v1.ElevatorControlSystem.Status:output_type -> lift.v1.SystemStatus
+	10, // 12: lift.v1.ElevatorControlSystem.WatchEvents:output_type -> lift.v1.Event
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_lift_proto_init() }
+func file_lift_proto_init() {
+	if File_lift_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lift_proto_rawDesc), len(file_lift_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lift_proto_goTypes,
+		DependencyIndexes: file_lift_proto_depIdxs,
+		MessageInfos:      file_lift_proto_msgTypes,
+	}.Build()
+	File_lift_proto = out.File
+	file_lift_proto_goTypes = nil
+	file_lift_proto_depIdxs = nil
+}
@@ -0,0 +1,290 @@
+// gRPC contract of the elevator control system, mirroring the ElevatorControlSystem interface of the lift package.
+// The directions, states, calls and kinds of events have the same names of the JSON Lines export and the REST API.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: lift.proto
+
+package liftpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ElevatorControlSystem_PickUp_FullMethodName      = "/lift.v1.ElevatorControlSystem/PickUp"
+	ElevatorControlSystem_Update_FullMethodName      = "/lift.v1.ElevatorControlSystem/Update"
+	ElevatorControlSystem_Step_FullMethodName        = "/lift.v1.ElevatorControlSystem/Step"
+	ElevatorControlSystem_Status_FullMethodName      = "/lift.v1.ElevatorControlSystem/Status"
+	ElevatorControlSystem_WatchEvents_FullMethodName = "/lift.v1.ElevatorControlSystem/WatchEvents"
+)
+
+// ElevatorControlSystemClient is the client API for ElevatorControlSystem service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ElevatorControlSystemClient interface {
+	// Registers the pick-up request of a user, like PickUpButtonWasPushed
+	PickUp(ctx context.Context, in *PickUpRequest, opts ...grpc.CallOption) (*PickUpResponse, error)
+	// Applies a maintenance override to an elevator, like Update, and tells its state afterwards
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*CarStatus, error)
+	// Advances the simulation, like Step, and tells the state of the system afterwards
+	Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*SystemStatus, error)
+	// Tells the state of every elevator, like Status
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*SystemStatus, error)
+	// Streams the events of the elevators while the system is stepped, like SubscribeChannel
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type elevatorControlSystemClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewElevatorControlSystemClient(cc grpc.ClientConnInterface) ElevatorControlSystemClient {
+	return &elevatorControlSystemClient{cc}
+}
+
+func (c *elevatorControlSystemClient) PickUp(ctx context.Context, in *PickUpRequest, opts ...grpc.CallOption) (*PickUpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickUpResponse)
+	err := c.cc.Invoke(ctx, ElevatorControlSystem_PickUp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorControlSystemClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*CarStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CarStatus)
+	err := c.cc.Invoke(ctx, ElevatorControlSystem_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorControlSystemClient) Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*SystemStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemStatus)
+	err := c.cc.Invoke(ctx, ElevatorControlSystem_Step_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorControlSystemClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*SystemStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemStatus)
+	err := c.cc.Invoke(ctx, ElevatorControlSystem_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *elevatorControlSystemClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ElevatorControlSystem_ServiceDesc.Streams[0], ElevatorControlSystem_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ElevatorControlSystem_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+// ElevatorControlSystemServer is the server API for ElevatorControlSystem service.
+// All implementations must embed UnimplementedElevatorControlSystemServer
+// for forward compatibility.
+type ElevatorControlSystemServer interface {
+	// Registers the pick-up request of a user, like PickUpButtonWasPushed
+	PickUp(context.Context, *PickUpRequest) (*PickUpResponse, error)
+	// Applies a maintenance override to an elevator, like Update, and tells its state afterwards
+	Update(context.Context, *UpdateRequest) (*CarStatus, error)
+	// Advances the simulation, like Step, and tells the state of the system afterwards
+	Step(context.Context, *StepRequest) (*SystemStatus, error)
+	// Tells the state of every elevator, like Status
+	Status(context.Context, *StatusRequest) (*SystemStatus, error)
+	// Streams the events of the elevators while the system is stepped, like SubscribeChannel
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedElevatorControlSystemServer()
+}
+
+// UnimplementedElevatorControlSystemServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedElevatorControlSystemServer struct{}
+
+func (UnimplementedElevatorControlSystemServer) PickUp(context.Context, *PickUpRequest) (*PickUpResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PickUp not implemented")
+}
+func (UnimplementedElevatorControlSystemServer) Update(context.Context, *UpdateRequest) (*CarStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedElevatorControlSystemServer) Step(context.Context, *StepRequest) (*SystemStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedElevatorControlSystemServer) Status(context.Context, *StatusRequest) (*SystemStatus, error) {
+	return nil, status.Error(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedElevatorControlSystemServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedElevatorControlSystemServer) mustEmbedUnimplementedElevatorControlSystemServer() {}
+func (UnimplementedElevatorControlSystemServer) testEmbeddedByValue()                               {}
+
+// UnsafeElevatorControlSystemServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ElevatorControlSystemServer will
+// result in compilation errors.
+type UnsafeElevatorControlSystemServer interface {
+	mustEmbedUnimplementedElevatorControlSystemServer()
+}
+
+func RegisterElevatorControlSystemServer(s grpc.ServiceRegistrar, srv ElevatorControlSystemServer) {
+	// If the following call panics, it indicates UnimplementedElevatorControlSystemServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ElevatorControlSystem_ServiceDesc, srv)
+}
+
+func _ElevatorControlSystem_PickUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorControlSystemServer).PickUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorControlSystem_PickUp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorControlSystemServer).PickUp(ctx, req.(*PickUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorControlSystem_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorControlSystemServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorControlSystem_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorControlSystemServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorControlSystem_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorControlSystemServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorControlSystem_Step_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorControlSystemServer).Step(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorControlSystem_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElevatorControlSystemServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ElevatorControlSystem_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElevatorControlSystemServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ElevatorControlSystem_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ElevatorControlSystemServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ElevatorControlSystem_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+// ElevatorControlSystem_ServiceDesc is the grpc.ServiceDesc for ElevatorControlSystem service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ElevatorControlSystem_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lift.v1.ElevatorControlSystem",
+	HandlerType: (*ElevatorControlSystemServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PickUp",
+			Handler:    _ElevatorControlSystem_PickUp_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ElevatorControlSystem_Update_Handler,
+		},
+		{
+			MethodName: "Step",
+			Handler:    _ElevatorControlSystem_Step_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _ElevatorControlSystem_Status_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _ElevatorControlSystem_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "lift.proto",
+}
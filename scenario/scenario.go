@@ -0,0 +1,350 @@
+/*
+Package scenario loads the scenarios of the elevator control system from YAML or JSON files, runs them and checks
+their expected outcomes, so they can be written without Go code:
+
+	name: Morning rush
+	building: {floors: 10, elevators: 2}
+	dispatcher: eta
+	cars:
+	  - {id: 1, out_of_service: true}
+	pickups:
+	  - {at: 0, user: User1, from: 0, to: 7}
+	  - {at: 3, user: User2, from: 4, to: 4, error: same_floor}
+	expect:
+	  completed: 1
+	  max_wait_time: 5
+	  users:
+	    - {user: User1, elevator: 0, arrives_by: 20}
+*/
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ArturoTarinVillaescusa/lift-go/lift"
+)
+
+/***********************************************************************
+ ***********************************************************************
+ ***                                                                 ***
+ ***               SCENARIO FILE FORMAT                              ***
+ ***                                                                 ***
+ ***********************************************************************
+ ***********************************************************************/
+
+// A building, its elevators, the users calling them and what should happen to them
+type Scenario struct {
+	Name        string       `json:"name" yaml:"name"`
+	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Building    Building     `json:"building" yaml:"building"`
+	Dispatcher  string       `json:"dispatcher,omitempty" yaml:"dispatcher,omitempty"` // One of Dispatchers, proximity by default
+	Policy      string       `json:"policy,omitempty" yaml:"policy,omitempty"`         // One of Policies, follow by default
+	Cars        []Car        `json:"cars,omitempty" yaml:"cars,omitempty"`
+	PickUps     []PickUp     `json:"pickups" yaml:"pickups"`
+	Expect      Expectations `json:"expect" yaml:"expect"`
+}
+
+// The size of the building, and the capacity of all its elevators, 8 persons and 630 kg if it's not given
+type Building struct {
+	Floors    int `json:"floors" yaml:"floors"` // Top floor, the ground floor is 0
+	Elevators int `json:"elevators" yaml:"elevators"`
+	Persons   int `json:"persons,omitempty" yaml:"persons,omitempty"`
+	RatedLoad int `json:"rated_load,omitempty" yaml:"rated_load,omitempty"` // Kilograms
+}
+
+// The settings of one of the elevators, which override the ones of the building
+type Car struct {
+	ID           int    `json:"id" yaml:"id"`
+	Floor        *int   `json:"floor,omitempty" yaml:"floor,omitempty"` // Where it is when the scenario starts
+	Persons      int    `json:"persons,omitempty" yaml:"persons,omitempty"`
+	RatedLoad    int    `json:"rated_load,omitempty" yaml:"rated_load,omitempty"`
+	Policy       string `json:"policy,omitempty" yaml:"policy,omitempty"`
+	OutOfService bool   `json:"out_of_service,omitempty" yaml:"out_of_service,omitempty"`
+}
+
+// A user pushing the pick-up button at a time. Error is the name of the error the request is rejected with, if any
+type PickUp struct {
+	At    int    `json:"at" yaml:"at"`
+	User  string `json:"user" yaml:"user"`
+	From  int    `json:"from" yaml:"from"`
+	To    int    `json:"to" yaml:"to"`
+	Error string `json:"error,omitempty" yaml:"error,omitempty"` // One of Errors
+}
+
+// What should happen once the elevators have no more trips. Only the expectations given are checked
+type Expectations struct {
+	Completed      *int              `json:"completed,omitempty" yaml:"completed,omitempty"`
+	Failed         *int              `json:"failed,omitempty" yaml:"failed,omitempty"`
+	MaxWaitTime    *int              `json:"max_wait_time,omitempty" yaml:"max_wait_time,omitempty"`
+	MaxJourneyTime *int              `json:"max_journey_time,omitempty" yaml:"max_journey_time,omitempty"`
+	MaxDuration    *int              `json:"max_duration,omitempty" yaml:"max_duration,omitempty"`
+	Users          []UserExpectation `json:"users,omitempty" yaml:"users,omitempty"`
+}
+
+// What should happen to a user: which elevator takes them, and when they arrive at the latest
+type UserExpectation struct {
+	User      string `json:"user" yaml:"user"`
+	Elevator  *int   `json:"elevator,omitempty" yaml:"elevator,omitempty"`
+	ArrivesBy *int   `json:"arrives_by,omitempty" yaml:"arrives_by,omitempty"`
+}
+
+// Dispatchers that can be chosen by their names
+var Dispatchers = map[string]lift.Dispatcher{
+	"proximity": lift.ProximityDispatcher{},
+	"eta":       lift.ETADispatcher{},
+	"energy":    lift.EnergyDispatcher{},
+}
+
+// Movement policies that can be chosen by their names
+var Policies = map[string]lift.MovementPolicy{
+	"follow": lift.FollowTaskList,
+	"look":   lift.LOOK,
+	"scan":   lift.SCAN,
+}
+
+// Errors a pick-up request can be rejected with, by their names
+var Errors = map[string]error{
+	"floor_out_of_range": lift.ErrFloorOutOfRange,
+	"same_floor":         lift.ErrSameFloor,
+}
+
+/**
+ *	Loads a scenario from a file, in YAML if its extension is .yaml or .yml, or in JSON if it's .json
+	@ path string
+*/
+func Load(path string) (*Scenario, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	scenario, err := Decode(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return scenario, nil
+}
+
+/**
+ *	Decodes a scenario in YAML, the yaml or yml format, or in JSON, the json format, and checks it. The unknown
+	fields are rejected, so the typos don't go unnoticed
+	@ r io.Reader
+	@ format string
+*/
+func Decode(r io.Reader, format string) (*Scenario, error) {
+	scenario := &Scenario{}
+	switch format {
+	case "yaml", "yml":
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(scenario); err != nil {
+			return nil, err
+		}
+	case "json":
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(scenario); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown scenario format %q, it must be yaml, yml or json", format)
+	}
+	return scenario, scenario.Validate()
+}
+
+// Checks that the scenario can be run: its building, its elevators, and the names it uses
+func (scenario *Scenario) Validate() error {
+	if scenario.Building.Floors < 1 || scenario.Building.Elevators < 1 {
+		return fmt.Errorf("the building needs at least 1 elevator and 1 floor besides the ground floor")
+	}
+	if _, ok := Dispatchers[scenario.Dispatcher]; scenario.Dispatcher != "" && !ok {
+		return fmt.Errorf("unknown dispatcher %q, it must be one of %v", scenario.Dispatcher, Names(Dispatchers))
+	}
+	if _, ok := Policies[scenario.Policy]; scenario.Policy != "" && !ok {
+		return fmt.Errorf("unknown policy %q, it must be one of %v", scenario.Policy, Names(Policies))
+	}
+	for _, car := range scenario.Cars {
+		if car.ID < 0 || car.ID >= scenario.Building.Elevators {
+			return fmt.Errorf("unknown car %d, it must be between 0 and %d", car.ID, scenario.Building.Elevators-1)
+		}
+		if _, ok := Policies[car.Policy]; car.Policy != "" && !ok {
+			return fmt.Errorf("car %d: unknown policy %q, it must be one of %v", car.ID, car.Policy, Names(Policies))
+		}
+		if car.Floor != nil && (*car.Floor < 0 || *car.Floor > scenario.Building.Floors) {
+			return fmt.Errorf("car %d: floor %d is not between 0 and %d", car.ID, *car.Floor, scenario.Building.Floors)
+		}
+	}
+	for _, pickUp := range scenario.PickUps {
+		if _, ok := Errors[pickUp.Error]; pickUp.Error != "" && !ok {
+			return fmt.Errorf("%v: unknown error %q, it must be one of %v", pickUp.User, pickUp.Error, Names(Errors))
+		}
+	}
+	return nil
+}
+
+/**
+ *	Builds the elevator control system of the scenario, with its elevators where the scenario says, but without
+	its users yet
+*/
+func (scenario *Scenario) NewSystem() (lift.ElevatorControlSystem, error) {
+	options := []lift.Option{}
+	if dispatcher, ok := Dispatchers[scenario.Dispatcher]; ok {
+		options = append(options, lift.WithDispatcher(dispatcher))
+	}
+	if policy, ok := Policies[scenario.Policy]; ok {
+		options = append(options, lift.WithMovementPolicy(policy))
+	}
+	if scenario.Building.Persons > 0 || scenario.Building.RatedLoad > 0 {
+		options = append(options, lift.WithCapacity(scenario.Building.Persons, scenario.Building.RatedLoad))
+	}
+	for _, car := range scenario.Cars {
+		if car.Persons > 0 || car.RatedLoad > 0 {
+			options = append(options, lift.WithCarCapacity(car.ID, car.Persons, car.RatedLoad))
+		}
+		if policy, ok := Policies[car.Policy]; ok {
+			options = append(options, lift.WithCarMovementPolicy(car.ID, policy))
+		}
+	}
+	control, err := lift.NewElevatorControlSystem(scenario.Building.Elevators, scenario.Building.Floors, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, car := range scenario.Cars {
+		if car.Floor != nil {
+			if err := control.Update(car.ID, *car.Floor, string(lift.STOPPED)); err != nil {
+				return nil, fmt.Errorf("car %d: %w", car.ID, err)
+			}
+		}
+		if car.OutOfService {
+			if err := control.Update(car.ID, 0, string(lift.OUT_OF_SERVICE)); err != nil {
+				return nil, fmt.Errorf("car %d: %w", car.ID, err)
+			}
+		}
+	}
+	return control, nil
+}
+
+/**
+ *	Schedules the pick-up requests of the scenario in the system. It fails if a request is not rejected with its
+	expected error
+	@ control lift.ElevatorControlSystem
+*/
+func (scenario *Scenario) Schedule(control lift.ElevatorControlSystem) error {
+	for _, pickUp := range scenario.PickUps {
+		err := control.SchedulePickUp(pickUp.At, pickUp.User, pickUp.From, pickUp.To)
+		if expected := Errors[pickUp.Error]; !errors.Is(err, expected) || expected == nil && err != nil {
+			return fmt.Errorf("%v: expected the error %v, got %v", pickUp.User, expected, err)
+		}
+	}
+	return nil
+}
+
+/**
+ *	Runs the scenario: builds its system, schedules its users and moves the elevators until no one has pending
+	trips and there are no more scheduled pick-up requests, with RunUntilIdle
+*/
+func (scenario *Scenario) Run() (lift.ElevatorControlSystem, error) {
+	control, err := scenario.NewSystem()
+	if err != nil {
+		return nil, err
+	}
+	if err := scenario.Schedule(control); err != nil {
+		return nil, err
+	}
+	return control, control.RunUntilIdle(context.Background())
+}
+
+/**
+ *	Tells which expectations of the scenario are not met by a system once it has run, one error per expectation
+	@ control lift.ElevatorControlSystem
+*/
+func (scenario *Scenario) Check(control lift.ElevatorControlSystem) []error {
+	report := control.Report()
+	expect := scenario.Expect
+	failures := []error{}
+	for _, check := range []struct {
+		name     string
+		expected *int
+		got      int
+		atMost   bool
+	}{
+		{"completed journeys", expect.Completed, report.Completed, false},
+		{"failed journeys", expect.Failed, report.Failed, false},
+		{"wait time", expect.MaxWaitTime, report.WaitTime.Max, true},
+		{"journey time", expect.MaxJourneyTime, report.JourneyTime.Max, true},
+		{"duration", expect.MaxDuration, report.Duration, true},
+	} {
+		if check.expected == nil || check.got == *check.expected || check.atMost && check.got < *check.expected {
+			continue
+		}
+		if check.atMost {
+			failures = append(failures, fmt.Errorf("expected a %v of %d at most, got %d", check.name, *check.expected, check.got))
+		} else {
+			failures = append(failures, fmt.Errorf("expected %d %v, got %d", *check.expected, check.name, check.got))
+		}
+	}
+
+	for _, user := range expect.Users {
+		journeys, err := control.Passenger(user.User)
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		journey := journeys[len(journeys)-1]
+		if user.Elevator != nil && journey.ElevatorID != *user.Elevator {
+			failures = append(failures, fmt.Errorf("%v: expected the elevator %d, got %d", user.User, *user.Elevator, journey.ElevatorID))
+		}
+		if user.ArrivesBy != nil && (!journey.Completed() || journey.AlightedAt > *user.ArrivesBy) {
+			failures = append(failures, fmt.Errorf("%v: expected to arrive by %d, got %+v", user.User, *user.ArrivesBy, journey))
+		}
+	}
+	return failures
+}
+
+// The names of the choices of a setting, sorted and separated by commas
+func Names[T any](choices map[string]T) string {
+	names := []string{}
+	for name := range choices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+/**
+ *	Encodes the scenario in YAML or in JSON, so the scenarios written in Go can be turned into files
+	@ w io.Writer
+	@ format string
+*/
+func (scenario *Scenario) Encode(w io.Writer, format string) error {
+	switch format {
+	case "yaml", "yml":
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(scenario); err != nil {
+			return err
+		}
+		_, err := w.Write(buffer.Bytes())
+		return err
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(scenario)
+	}
+	return fmt.Errorf("unknown scenario format %q, it must be yaml, yml or json", format)
+}
@@ -0,0 +1,144 @@
+package scenario
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The scenarios shared with the command line tool, written by anyone in YAML or JSON
+const scenarios = "../scenarios"
+
+func scenarioFiles(t testing.TB) []string {
+	files := []string{}
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(scenarios, pattern))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no scenarios in %v", scenarios)
+	}
+	return files
+}
+
+func TestScenarioFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range scenarioFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+
+			scenario, err := Load(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			control, err := scenario.Run()
+			if err != nil {
+				t.Fatalf("%v: %v", scenario.Name, err)
+			}
+			for _, failure := range scenario.Check(control) {
+				t.Errorf("%v: %v", scenario.Name, failure)
+			}
+		})
+	}
+}
+
+func TestInvalidScenariosAreRejected(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		name     string
+		format   string
+		scenario string
+		err      string
+	}{
+		{"unknown format", "toml", `name = "x"`, "unknown scenario format"},
+		{"unknown field", "yaml", "building: {floors: 5, elevators: 1, lifts: 2}", "field lifts not found"},
+		{"unknown JSON field", "json", `{"building": {"floors": 5, "elevators": 1}, "pickup": []}`, `unknown field "pickup"`},
+		{"no elevators", "yaml", "building: {floors: 5}", "at least 1 elevator"},
+		{"unknown dispatcher", "yaml", "building: {floors: 5, elevators: 1}\ndispatcher: nearest", `unknown dispatcher "nearest"`},
+		{"unknown policy", "yml", "building: {floors: 5, elevators: 1}\npolicy: elevator", `unknown policy "elevator"`},
+		{"unknown car", "yaml", "building: {floors: 5, elevators: 1}\ncars: [{id: 1}]", "unknown car 1"},
+		{"car out of the building", "yaml", "building: {floors: 5, elevators: 1}\ncars: [{id: 0, floor: 6}]", "floor 6 is not between 0 and 5"},
+		{"unknown error", "yaml", "building: {floors: 5, elevators: 1}\npickups: [{user: User1, from: 1, to: 1, error: same}]", `unknown error "same"`},
+	} {
+		_, err := Decode(strings.NewReader(c.scenario), c.format)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%v: expected an error with %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestUnexpectedPickUpErrorsAndUnmetExpectationsAreReported(t *testing.T) {
+	t.Parallel()
+
+	scenario, err := Decode(strings.NewReader(`
+building: {floors: 5, elevators: 1}
+pickups:
+  - {at: 0, user: User1, from: 0, to: 5}
+expect: {completed: 2, max_wait_time: 0, users: [{user: User1, elevator: 1, arrives_by: 1}, {user: User2}]}
+`), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	control, err := scenario.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	failures := scenario.Check(control)
+	if len(failures) != 5 {
+		t.Errorf("expected 5 unmet expectations, got %v", failures)
+	}
+
+	scenario.PickUps[0].Error = "same_floor"
+	if _, err := scenario.Run(); err == nil || !strings.Contains(err.Error(), "User1: expected the error") {
+		t.Errorf("expected the pick-up error to be reported, got %v", err)
+	}
+}
+
+func TestEncodedScenariosAreDecodedAgain(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"yaml", "json"} {
+		scenario, err := Load(scenarioFiles(t)[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buffer bytes.Buffer
+		if err := scenario.Encode(&buffer, format); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := Decode(&buffer, format)
+		if err != nil {
+			t.Fatalf("%v: %v", format, err)
+		}
+		control, err := decoded.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failures := decoded.Check(control); len(failures) > 0 {
+			t.Errorf("%v: %v", format, failures)
+		}
+	}
+}
+
+func BenchmarkScenarioFiles(b *testing.B) {
+	loaded := []*Scenario{}
+	for _, file := range scenarioFiles(b) {
+		scenario, err := Load(file)
+		if err != nil {
+			b.Fatal(err)
+		}
+		loaded = append(loaded, scenario)
+	}
+
+	for i := 0; i < b.N; i++ {
+		for _, scenario := range loaded {
+			scenario.Run()
+		}
+	}
+}